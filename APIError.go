@@ -0,0 +1,61 @@
+package binance
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+)
+
+// Error codes returned by Binance that this package acts on.
+const (
+	ErrorCodeUnknown                      = -1000
+	ErrorCodeNoSuchOrder                  = -2013
+	ErrorCodeCancelReplacePartiallyFailed = -2021
+	ErrorCodeCancelReplaceFailed          = -2022
+	ErrorCodeNoNeedToChangeMarginType     = -4046
+)
+
+// APIError is an error returned by Binance. It's returned by both the REST
+// and the websocket API.
+type APIError struct {
+	// StatusCode is the HTTP status code, or the status of the websocket
+	// API response.
+	StatusCode int `json:"-"`
+
+	Code    int    `json:"code"`
+	Message string `json:"msg"`
+
+	// Data is additional details about the error. Only a few endpoints
+	// return this.
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+// Error implements error.
+func (e *APIError) Error() string {
+	if e.Code == 0 && e.Message == "" {
+		return fmt.Sprintf("got http status code %d", e.StatusCode)
+	}
+
+	return fmt.Sprintf("binance error %d: %s", e.Code, e.Message)
+}
+
+// unknownExecution returns true if err leaves us without knowing whether a
+// request was executed. That is the case for timeouts and 5xx responses.
+func unknownExecution(err error) bool {
+	switch e := err.(type) {
+	case *APIError:
+		return e.StatusCode >= http.StatusInternalServerError
+	case net.Error:
+		return e.Timeout()
+	}
+
+	return false
+}
+
+// noSuchOrder returns true if err tells that an order does not exist.
+func noSuchOrder(err error) bool {
+	e, ok := err.(*APIError)
+
+	return ok && e.Code == ErrorCodeNoSuchOrder
+}
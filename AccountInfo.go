@@ -1,19 +1,40 @@
 package binance
 
+// CommissionRates are the commission rates of an account as fractions, so
+// 0.001 is 0.1%.
+type CommissionRates struct {
+	Maker  Value `json:"maker"`
+	Taker  Value `json:"taker"`
+	Buyer  Value `json:"buyer"`
+	Seller Value `json:"seller"`
+}
+
 // AccountInfo describes the current account.
 type AccountInfo struct {
-	MakerCommission  int  `json:"makerCommission"`
-	TakerCommission  int  `json:"takerCommission"`
-	BuyerCommission  int  `json:"buyerCommission"`
-	SellerCommission int  `json:"sellerCommission"`
-	CanTrade         bool `json:"canTrade"`
-	CanWithdraw      bool `json:"canWithdraw"`
-	CanDeposit       bool `json:"canDeposit"`
-	Balances         []struct {
-		Asset  string `json:"asset"`
-		Free   Value  `json:"free"`
-		Locked Value  `json:"locked"`
-	} `json:"balances"`
+	MakerCommission            int             `json:"makerCommission"`
+	TakerCommission            int             `json:"takerCommission"`
+	BuyerCommission            int             `json:"buyerCommission"`
+	SellerCommission           int             `json:"sellerCommission"`
+	CommissionRates            CommissionRates `json:"commissionRates"`
+	CanTrade                   bool            `json:"canTrade"`
+	CanWithdraw                bool            `json:"canWithdraw"`
+	CanDeposit                 bool            `json:"canDeposit"`
+	Brokered                   bool            `json:"brokered"`
+	RequireSelfTradePrevention bool            `json:"requireSelfTradePrevention"`
+	UpdateTime                 Time            `json:"updateTime"`
+	AccountType                string          `json:"accountType"` // FIXME: type
+	Permissions                []string        `json:"permissions"` // FIXME: type
+	Balances                   []Balance       `json:"balances"`
+}
+
+// MakerRate returns the maker commission as a fraction, so 0.001 is 0.1%.
+func (a *AccountInfo) MakerRate() float64 {
+	return float64(a.MakerCommission) / 10000
+}
+
+// TakerRate returns the taker commission as a fraction, so 0.001 is 0.1%.
+func (a *AccountInfo) TakerRate() float64 {
+	return float64(a.TakerCommission) / 10000
 }
 
 // AccountInfo retrieves various information about the account.
@@ -0,0 +1,124 @@
+package binance
+
+import (
+	"math/big"
+	"sort"
+	"strings"
+)
+
+// Balance is the balance of a single asset.
+type Balance struct {
+	Asset  string `json:"asset"`
+	Free   Value  `json:"free"`
+	Locked Value  `json:"locked"`
+}
+
+// Total returns the free and locked balance combined.
+func (b Balance) Total() float64 {
+	return b.Free.Float64() + b.Locked.Float64()
+}
+
+// Zero returns true if nothing is free or locked.
+func (b Balance) Zero() bool {
+	return b.Free.Float64() == 0 && b.Locked.Float64() == 0
+}
+
+// BalanceChange is the change of the balance of an asset between two
+// snapshots of the account.
+type BalanceChange struct {
+	Asset  string
+	Free   Value
+	Locked Value
+}
+
+// valueDiff returns a-b without rounding errors. Values that can't be
+// decoded count as zero.
+func valueDiff(a Value, b Value) Value {
+	x, ok := new(big.Rat).SetString(string(a))
+	if !ok {
+		x = new(big.Rat)
+	}
+
+	y, ok := new(big.Rat).SetString(string(b))
+	if !ok {
+		y = new(big.Rat)
+	}
+
+	s := x.Sub(x, y).FloatString(8)
+	s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+
+	if s == "-0" {
+		s = "0"
+	}
+
+	return Value(s)
+}
+
+// Balance returns the balance of asset. If asset is not listed, a zero
+// balance is returned along with false.
+func (a *AccountInfo) Balance(asset string) (Balance, bool) {
+	for _, b := range a.Balances {
+		if b.Asset == asset {
+			return b, true
+		}
+	}
+
+	return Balance{Asset: asset, Free: "0", Locked: "0"}, false
+}
+
+// Total returns the free and locked balance of asset combined.
+func (a *AccountInfo) Total(asset string) float64 {
+	b, _ := a.Balance(asset)
+
+	return b.Total()
+}
+
+// NonZero returns the balances with something free or locked.
+func (a *AccountInfo) NonZero() []Balance {
+	var balances []Balance
+
+	for _, b := range a.Balances {
+		if !b.Zero() {
+			balances = append(balances, b)
+		}
+	}
+
+	return balances
+}
+
+// Diff returns the changes of balances from before until a, sorted by asset.
+// Assets without changes are left out.
+func (a *AccountInfo) Diff(before *AccountInfo) []BalanceChange {
+	assets := make(map[string]bool)
+
+	for _, b := range a.Balances {
+		assets[b.Asset] = true
+	}
+
+	for _, b := range before.Balances {
+		assets[b.Asset] = true
+	}
+
+	var changes []BalanceChange
+
+	for asset := range assets {
+		now, _ := a.Balance(asset)
+		then, _ := before.Balance(asset)
+
+		change := BalanceChange{
+			Asset:  asset,
+			Free:   valueDiff(now.Free, then.Free),
+			Locked: valueDiff(now.Locked, then.Locked),
+		}
+
+		if change.Free != "0" || change.Locked != "0" {
+			changes = append(changes, change)
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Asset < changes[j].Asset
+	})
+
+	return changes
+}
@@ -0,0 +1,52 @@
+package binance
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+func TestAccountInfoBalances(t *testing.T) {
+	data := `{"makerCommission":15,"takerCommission":15,"buyerCommission":0,"sellerCommission":0,"commissionRates":{"maker":"0.00150000","taker":"0.00150000","buyer":"0.00000000","seller":"0.00000000"},"canTrade":true,"canWithdraw":true,"canDeposit":true,"brokered":false,"requireSelfTradePrevention":false,"preventSor":false,"updateTime":123456789,"accountType":"SPOT","balances":[{"asset":"BTC","free":"4723846.89208129","locked":"0.00000000"},{"asset":"LTC","free":"4763368.68006011","locked":"0.10000000"},{"asset":"ETH","free":"0.00000000","locked":"0.00000000"}],"permissions":["SPOT"],"uid":354937868}`
+
+	var info AccountInfo
+	err := json.Unmarshal([]byte(data), &info)
+	if err != nil {
+		t.Fatalf("Unmarshal failed: %s", err.Error())
+	}
+
+	if info.CommissionRates.Maker != "0.00150000" || info.AccountType != "SPOT" || len(info.Permissions) != 1 ||
+		info.UpdateTime.UnixNano() != 123456789000000 {
+		t.Errorf("fields not decoded: %+v", info)
+	}
+
+	b, found := info.Balance("LTC")
+	if !found || b.Locked != "0.10000000" {
+		t.Errorf("Balance returned %+v %v", b, found)
+	}
+
+	b, found = info.Balance("XRP")
+	if found || !b.Zero() {
+		t.Errorf("Balance of unknown asset returned %+v %v", b, found)
+	}
+
+	if total := info.Total("LTC"); total != 4763368.78006011 {
+		t.Errorf("Total returned %f", total)
+	}
+
+	nonZero := fmt.Sprint(info.NonZero())
+	if nonZero != "[{BTC 4723846.89208129 0.00000000} {LTC 4763368.68006011 0.10000000}]" {
+		t.Errorf("NonZero returned %s", nonZero)
+	}
+
+	after := AccountInfo{Balances: []Balance{
+		{"BTC", "4723846.99208129", "0.00000000"},
+		{"LTC", "4763368.78006011", "0.00000000"},
+		{"XRP", "10", "0"},
+	}}
+
+	changes := fmt.Sprint(after.Diff(&info))
+	if changes != "[{BTC 0.1 0} {LTC 0.1 -0.1} {XRP 10 0}]" {
+		t.Errorf("Diff returned %s", changes)
+	}
+}
@@ -4,6 +4,10 @@ package binance
 type BestPrice struct {
 	Bid OrderBookPoint `json:"bid"`
 	Ask OrderBookPoint `json:"ask"`
+
+	// UpdateID is the order book update ID. It's only set for prices from
+	// the book ticker streams.
+	UpdateID int64 `json:"updateId,omitempty"`
 }
 
 type bestPriceProxy struct {
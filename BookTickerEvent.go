@@ -0,0 +1,27 @@
+package binance
+
+// BookTickerEvent is pushed by the book ticker streams every time the best
+// bid or ask changes.
+type BookTickerEvent struct {
+	UpdateID    int64  `json:"u"`
+	Symbol      Symbol `json:"s"`
+	BidPrice    Value  `json:"b"`
+	BidQuantity Value  `json:"B"`
+	AskPrice    Value  `json:"a"`
+	AskQuantity Value  `json:"A"`
+}
+
+// BestPrice returns the event as a BestPrice, including the update ID.
+func (e *BookTickerEvent) BestPrice() *BestPrice {
+	return &BestPrice{
+		Bid: OrderBookPoint{
+			Price:    e.BidPrice,
+			Quantity: e.BidQuantity,
+		},
+		Ask: OrderBookPoint{
+			Price:    e.AskPrice,
+			Quantity: e.AskQuantity,
+		},
+		UpdateID: e.UpdateID,
+	}
+}
@@ -0,0 +1,42 @@
+package binance
+
+import (
+	"fmt"
+
+	"golang.org/x/net/websocket"
+)
+
+// BookTickerStream represents a stream from the book ticker endpoint.
+type BookTickerStream struct {
+	*websocket.Conn
+}
+
+// Read a book ticker from the stream. This will block until a ticker is
+// ready.
+func (s *BookTickerStream) Read() (*BookTickerEvent, error) {
+	event := &BookTickerEvent{}
+
+	err := websocket.JSON.Receive(s.Conn, event)
+	if err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
+
+// BookTickerStream will open a websocket stream that will stream the best bid
+// and ask for symbol in real time. You should call Close() when done.
+func (c *Client) BookTickerStream(symbol Symbol) (*BookTickerStream, error) {
+	URL := fmt.Sprintf("%s/ws/%s", c.streamBaseURL, NewStreamID(symbol, StreamTypeBookTicker))
+
+	conn, err := websocket.Dial(URL, "", "http://localhost/")
+	if err != nil {
+		return nil, err
+	}
+
+	stream := &BookTickerStream{
+		Conn: conn,
+	}
+
+	return stream, nil
+}
@@ -0,0 +1,18 @@
+package binance
+
+// CancelReplaceMode decides what happens to the new order if the cancel
+// fails in CancelReplaceOrder().
+type CancelReplaceMode string
+
+// With CancelReplaceStopOnFailure the new order is only placed if the cancel
+// succeeded. With CancelReplaceAllowFailure the new order is placed
+// regardless.
+const (
+	CancelReplaceStopOnFailure CancelReplaceMode = "STOP_ON_FAILURE"
+	CancelReplaceAllowFailure  CancelReplaceMode = "ALLOW_FAILURE"
+)
+
+// String implement Stringer.
+func (m CancelReplaceMode) String() string {
+	return string(m)
+}
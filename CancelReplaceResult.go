@@ -0,0 +1,85 @@
+package binance
+
+import (
+	"encoding/json"
+)
+
+// CancelReplaceStatus is the outcome of one half of a cancel-replace.
+type CancelReplaceStatus string
+
+// The different outcomes.
+const (
+	CancelReplaceSuccess      CancelReplaceStatus = "SUCCESS"
+	CancelReplaceFailure      CancelReplaceStatus = "FAILURE"
+	CancelReplaceNotAttempted CancelReplaceStatus = "NOT_ATTEMPTED"
+)
+
+// CancelReplaceResult describes the outcome of CancelReplaceOrder(). For each
+// half either the order or the error will be set, unless it was not
+// attempted.
+type CancelReplaceResult struct {
+	CancelResult   CancelReplaceStatus
+	NewOrderResult CancelReplaceStatus
+
+	Canceled    *Order
+	CancelError *APIError
+
+	NewOrder      *Order
+	NewOrderError *APIError
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Binance will send either an
+// order or an error for each half.
+func (r *CancelReplaceResult) UnmarshalJSON(data []byte) error {
+	var proxy struct {
+		CancelResult     CancelReplaceStatus `json:"cancelResult"`
+		NewOrderResult   CancelReplaceStatus `json:"newOrderResult"`
+		CancelResponse   json.RawMessage     `json:"cancelResponse"`
+		NewOrderResponse json.RawMessage     `json:"newOrderResponse"`
+	}
+
+	err := json.Unmarshal(data, &proxy)
+	if err != nil {
+		return err
+	}
+
+	r.CancelResult = proxy.CancelResult
+	r.NewOrderResult = proxy.NewOrderResult
+
+	r.Canceled, r.CancelError, err = orderOrError(proxy.CancelResponse)
+	if err != nil {
+		return err
+	}
+
+	r.NewOrder, r.NewOrderError, err = orderOrError(proxy.NewOrderResponse)
+
+	return err
+}
+
+// orderOrError will decode data as either an order or an error.
+func orderOrError(data json.RawMessage) (*Order, *APIError, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return nil, nil, nil
+	}
+
+	var probe struct {
+		Code *int `json:"code"`
+	}
+
+	err := json.Unmarshal(data, &probe)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if probe.Code != nil {
+		apiErr := &APIError{}
+		err = json.Unmarshal(data, apiErr)
+
+		return nil, apiErr, err
+	}
+
+	order := &Order{}
+	err = json.Unmarshal(data, order)
+
+	return order, nil, err
+}
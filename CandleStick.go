@@ -66,6 +66,11 @@ func (c *Client) CandleStick(symbol Symbol, interval string, options ...QueryFun
 		return nil, err
 	}
 
+	return candleSticks(proxy)
+}
+
+// candleSticks converts proxy as returned by the API.
+func candleSticks(proxy []candleStickProxy) ([]CandleStick, error) {
 	sticks := make([]CandleStick, len(proxy))
 	for i, p := range proxy {
 		stick, err := p.real()
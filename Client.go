@@ -1,6 +1,7 @@
 package binance
 
 import (
+	"crypto/ed25519"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -10,6 +11,7 @@ import (
 	"net/url"
 	"os"
 	"strconv"
+	"sync"
 	"time"
 )
 
@@ -17,11 +19,27 @@ import (
 type Client struct {
 	apiKey        string
 	apiSecret     string
+	ed25519Key    ed25519.PrivateKey
 	streamBaseURL string
+	wsAPIBaseURL  string
+	wsAPITimeout  time.Duration
 	baseURL       string
 	client        *http.Client
 	dumpWriter    io.Writer
-	usedWeight    int
+	weight        *weightLimiter
+
+	// paper answers the account and order requests when paper trading.
+	paper *PaperAccount
+
+	clientOrderIDPrefix string
+	submitRetries       int
+
+	withdrawAllowlist []AllowedAddress
+	withdrawMu        sync.Mutex
+
+	// withdrawn maps the withdrawOrderId of withdrawals submitted to the
+	// ID of the withdrawal, or an empty string while it's unknown.
+	withdrawn map[string]string
 }
 
 // APIKey will parse the API key to the client. This is not needed for all
@@ -40,6 +58,15 @@ func APISecret(apiSecret string) func(*Client) {
 	}
 }
 
+// Ed25519Key will use an Ed25519 private key for signing requests instead of
+// the API secret. The key must be registered with Binance along with the API
+// key. Ed25519 keys are required for logging on to a websocket API session.
+func Ed25519Key(key ed25519.PrivateKey) func(*Client) {
+	return func(c *Client) {
+		c.ed25519Key = key
+	}
+}
+
 // FromEnvironment will read the keys BINANCE_KEY and BINANCE_SECRET (if
 // set) from the environment and use them as credentials.
 func FromEnvironment() func(*Client) {
@@ -72,6 +99,22 @@ func StreamBaseURL(streamBaseURL string) func(*Client) {
 	}
 }
 
+// WSAPIBaseURL changes the URL for the websocket API. You would probably
+// never use this.
+func WSAPIBaseURL(wsAPIBaseURL string) func(*Client) {
+	return func(c *Client) {
+		c.wsAPIBaseURL = wsAPIBaseURL
+	}
+}
+
+// WSAPITimeout sets how long to wait for a response from the websocket API.
+// The default is 10 seconds. Zero means no timeout.
+func WSAPITimeout(timeout time.Duration) func(*Client) {
+	return func(c *Client) {
+		c.wsAPITimeout = timeout
+	}
+}
+
 // HTTPClient will change the HTTP client to use. Default is
 // http.DefaultClient. This can be used for example if you would like to use
 // the AppEngine http client.
@@ -81,6 +124,15 @@ func HTTPClient(client *http.Client) func(*Client) {
 	}
 }
 
+// SubmitRetries sets how many times SubmitOrder() will retry submitting an
+// order, if Binance can't tell whether the order was accepted and the order
+// can't be found afterwards. The default is to never retry.
+func SubmitRetries(retries int) func(*Client) {
+	return func(c *Client) {
+		c.submitRetries = retries
+	}
+}
+
 // DumpWriter will instruct Client to dump all HTTP requests and responses to
 // and from Binance to w.
 func DumpWriter(w io.Writer) func(*Client) {
@@ -94,7 +146,10 @@ func NewClient(options ...func(*Client)) (*Client, error) {
 	client := &Client{
 		baseURL:       "https://api.binance.com",
 		streamBaseURL: "wss://stream.binance.com:9443",
+		wsAPIBaseURL:  "wss://ws-api.binance.com:443/ws-api/v3",
+		wsAPITimeout:  10 * time.Second,
 		client:        http.DefaultClient,
+		weight:        &weightLimiter{},
 	}
 
 	client.SetOptions(options...)
@@ -128,6 +183,22 @@ func param(key string, value interface{}) func(url.Values) {
 			v.Add(key, string(t))
 		case TimeInForce:
 			v.Add(key, string(t))
+		case SelfTradePreventionMode:
+			v.Add(key, string(t))
+		case TransferType:
+			v.Add(key, string(t))
+		case TransferAccount:
+			v.Add(key, string(t))
+		case SideEffectType:
+			v.Add(key, string(t))
+		case FuturesOrderType:
+			v.Add(key, string(t))
+		case PositionSide:
+			v.Add(key, string(t))
+		case MarginType:
+			v.Add(key, string(t))
+		case WorkingType:
+			v.Add(key, string(t))
 		default:
 			panic(fmt.Sprintf("unsupported value type: %T", value))
 		}
@@ -154,12 +225,24 @@ func (c *Client) doRequest(target interface{}, req *http.Request) error {
 		fmt.Fprintf(c.dumpWriter, "HTTP Request:\n%s\n", string(r))
 	}
 
-	response, err := c.client.Do(req)
+	c.weight.wait()
+
+	var response *http.Response
+	var err error
+
+	if c.paper != nil {
+		response, err = c.paper.serve(req, c.client.Do)
+	} else {
+		response, err = c.client.Do(req)
+	}
+
 	if err != nil {
 		return err
 	}
 	defer response.Body.Close()
 
+	c.weight.update(time.Now(), response)
+
 	if c.dumpWriter != nil {
 		r, err := httputil.DumpResponse(response, true)
 		if err != nil {
@@ -169,11 +252,14 @@ func (c *Client) doRequest(target interface{}, req *http.Request) error {
 	}
 
 	if response.StatusCode >= http.StatusBadRequest {
-		return fmt.Errorf("got http status code %d", response.StatusCode)
-	}
+		apiErr := &APIError{}
+
+		// Not all errors carry a body we can decode. In that case we simply
+		// return the status code.
+		_ = json.NewDecoder(response.Body).Decode(apiErr)
+		apiErr.StatusCode = response.StatusCode
 
-	if uw, err := strconv.Atoi(response.Header.Get("X-Mbx-Used-Weight")); err != nil {
-		c.usedWeight = uw
+		return apiErr
 	}
 
 	if target == nil {
@@ -186,7 +272,7 @@ func (c *Client) doRequest(target interface{}, req *http.Request) error {
 
 // UsedWeight will return the total weight used in the present minute.
 func (c *Client) UsedWeight() int {
-	return c.usedWeight
+	return c.weight.usedWeight()
 }
 
 func (c *Client) publicGet(target interface{}, uri string, params ...func(url.Values)) error {
@@ -196,11 +282,15 @@ func (c *Client) publicGet(target interface{}, uri string, params ...func(url.Va
 }
 
 func (c *Client) marketGet(target interface{}, uri string, params ...func(url.Values)) error {
+	return c.keyedCall(target, "GET", uri, params...)
+}
+
+func (c *Client) keyedCall(target interface{}, method string, uri string, params ...func(url.Values)) error {
 	if c.apiKey == "" {
 		return errors.New("no API key set")
 	}
 
-	req, _ := c.buildRequest("GET", uri, params...)
+	req, _ := c.buildRequest(method, uri, params...)
 
 	req.Header.Add("X-MBX-APIKEY", c.apiKey)
 
@@ -208,7 +298,7 @@ func (c *Client) marketGet(target interface{}, uri string, params ...func(url.Va
 }
 
 func (c *Client) signedCall(target interface{}, method string, uri string, params ...func(url.Values)) error {
-	if c.apiSecret == "" {
+	if c.apiSecret == "" && c.ed25519Key == nil {
 		return errors.New("no API secret set")
 	}
 
@@ -223,14 +313,24 @@ func (c *Client) signedCall(target interface{}, method string, uri string, param
 	// Add a signature to the request. It will be safe to simply add it here
 	// using '&', since timestamp will always be set and we will never
 	// encounter an empty query string.
-	signature := signString(req.URL.RawQuery, c.apiSecret)
-	req.URL.RawQuery += "&signature=" + signature
+	signature := c.sign(req.URL.RawQuery)
+	req.URL.RawQuery += "&signature=" + url.QueryEscape(signature)
 
 	req.Header.Add("X-MBX-APIKEY", c.apiKey)
 
 	return c.doRequest(target, req)
 }
 
+// sign will sign payload using the Ed25519 key if set, or the API secret if
+// not.
+func (c *Client) sign(payload string) string {
+	if c.ed25519Key != nil {
+		return signEd25519(payload, c.ed25519Key)
+	}
+
+	return signString(payload, c.apiSecret)
+}
+
 // Ping will ping the Binance API and return a RTT duration and an error if
 // something went wrong.
 func (c *Client) Ping() (time.Duration, error) {
@@ -0,0 +1,86 @@
+package binance
+
+import (
+	"crypto/rand"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// clientOrderIDMaxLength is the maximum length of a client order ID accepted
+// by Binance.
+const clientOrderIDMaxLength = 36
+
+// clientOrderIDChars is the characters allowed in a client order ID.
+const clientOrderIDChars = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789.:/_-"
+
+// clientOrderIDCounter makes sure IDs are unique within the process, even if
+// created within the same millisecond.
+var clientOrderIDCounter uint32
+
+// ClientOrderIDPrefix will prefix all client order IDs generated by the
+// client with prefix. This can be used to tell orders from different
+// strategies apart. Characters not allowed by Binance will be removed, and
+// the prefix will be truncated to leave room for the unique part.
+func ClientOrderIDPrefix(prefix string) func(*Client) {
+	prefix = strings.Map(func(r rune) rune {
+		if !strings.ContainsRune(clientOrderIDChars, r) {
+			return -1
+		}
+
+		return r
+	}, prefix)
+
+	if len(prefix) > clientOrderIDMaxLength-20 {
+		prefix = prefix[:clientOrderIDMaxLength-20]
+	}
+
+	return func(c *Client) {
+		c.clientOrderIDPrefix = prefix
+	}
+}
+
+// NewClientOrderID will return a new unique client order ID. It consists of
+// the prefix set by ClientOrderIDPrefix(), the current time, a counter and a
+// few random characters in case more processes are submitting orders.
+func (c *Client) NewClientOrderID() string {
+	const random = 6
+
+	ms := time.Now().UnixNano() / int64(time.Millisecond)
+	counter := atomic.AddUint32(&clientOrderIDCounter, 1)
+
+	b := make([]byte, random)
+	_, _ = rand.Read(b)
+	for i := range b {
+		// We only use the alphanumerics, making the ID easier on the eyes.
+		b[i] = clientOrderIDChars[int(b[i])%62]
+	}
+
+	id := c.clientOrderIDPrefix +
+		strconv.FormatInt(ms, 36) +
+		strconv.FormatUint(uint64(counter%(36*36*36)), 36) +
+		string(b)
+
+	if len(id) > clientOrderIDMaxLength {
+		id = id[:clientOrderIDMaxLength]
+	}
+
+	return id
+}
+
+// validClientOrderID returns true if id is accepted by Binance as a client
+// order ID.
+func validClientOrderID(id string) bool {
+	if len(id) == 0 || len(id) > clientOrderIDMaxLength {
+		return false
+	}
+
+	for _, r := range id {
+		if !strings.ContainsRune(clientOrderIDChars, r) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,58 @@
+package binance
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewClientOrderID(t *testing.T) {
+	cases := []struct {
+		prefix   string
+		expected string
+	}{
+		{"", ""},
+		{"twap-", "twap-"},
+		{"bad prefix!", "badprefix"},
+		{"a-very-long-prefix-that-will-not-fit", "a-very-long-pref"},
+	}
+
+	for _, c := range cases {
+		client, _ := NewClient(ClientOrderIDPrefix(c.prefix))
+
+		seen := make(map[string]bool)
+		for i := 0; i < 1000; i++ {
+			id := client.NewClientOrderID()
+
+			if !validClientOrderID(id) {
+				t.Fatalf("%s is not a valid client order ID", id)
+			}
+
+			if !strings.HasPrefix(id, c.expected) {
+				t.Fatalf("%s does not have prefix '%s'", id, c.expected)
+			}
+
+			if seen[id] {
+				t.Fatalf("%s generated twice", id)
+			}
+			seen[id] = true
+		}
+	}
+}
+
+func TestValidClientOrderID(t *testing.T) {
+	cases := map[string]bool{
+		"":                                      false,
+		"abc":                                   true,
+		"A.B:C/D_E-F":                           true,
+		"with space":                            false,
+		"æøå":                                   false,
+		"123456789012345678901234567890123456":  true,
+		"1234567890123456789012345678901234567": false,
+	}
+
+	for id, expected := range cases {
+		if validClientOrderID(id) != expected {
+			t.Errorf("validClientOrderID(%s) returned %v", id, !expected)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package binance
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// ClientPool holds one client per account, identified by a label. The
+// clients share the request weight limit, as Binance counts weight per IP
+// and not per account.
+type ClientPool struct {
+	options []func(*Client)
+	weight  *weightLimiter
+
+	mu      sync.Mutex
+	clients map[string]*Client
+}
+
+// NewClientPool returns an empty pool. options are applied to all clients
+// in the pool, before the options given to Add(). WeightLimit() sets the
+// limit shared by all clients.
+func NewClientPool(options ...func(*Client)) *ClientPool {
+	// The options decide how the shared limiter is set up.
+	probe, _ := NewClient(options...)
+
+	return &ClientPool{
+		options: options,
+		weight:  probe.weight,
+		clients: make(map[string]*Client),
+	}
+}
+
+// Add will add a client for the account with label to the pool. options
+// are usually the credentials of the account.
+func (p *ClientPool) Add(label string, options ...func(*Client)) (*Client, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, found := p.clients[label]; found {
+		return nil, fmt.Errorf("the pool already has an account labeled %s", label)
+	}
+
+	all := make([]func(*Client), 0, len(p.options)+len(options))
+	all = append(all, p.options...)
+	all = append(all, options...)
+
+	client, err := NewClient(all...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.weight = p.weight
+	p.clients[label] = client
+
+	return client, nil
+}
+
+// Remove will remove the account with label from the pool.
+func (p *ClientPool) Remove(label string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.clients, label)
+}
+
+// Client returns the client of the account with label.
+func (p *ClientPool) Client(label string) (*Client, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	client, found := p.clients[label]
+
+	return client, found
+}
+
+// Labels returns the labels of all accounts in the pool, sorted.
+func (p *ClientPool) Labels() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	labels := make([]string, 0, len(p.clients))
+	for label := range p.clients {
+		labels = append(labels, label)
+	}
+
+	sort.Strings(labels)
+
+	return labels
+}
+
+// Each will call f for all accounts in the pool concurrently, and wait for
+// all calls to return. The errors returned by f are returned by label. If
+// all calls succeed, the map is empty.
+func (p *ClientPool) Each(f func(label string, client *Client) error) map[string]error {
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+
+	errs := make(map[string]error)
+
+	for _, label := range p.Labels() {
+		client, found := p.Client(label)
+		if !found {
+			continue
+		}
+
+		wg.Add(1)
+
+		go func(label string, client *Client) {
+			defer wg.Done()
+
+			err := f(label, client)
+			if err != nil {
+				mu.Lock()
+				errs[label] = err
+				mu.Unlock()
+			}
+		}(label, client)
+	}
+
+	wg.Wait()
+
+	return errs
+}
@@ -0,0 +1,73 @@
+package binance
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestClientPool(t *testing.T) {
+	var mu sync.Mutex
+	keys := make(map[string]bool)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		keys[r.Header.Get("X-MBX-APIKEY")] = true
+		mu.Unlock()
+
+		w.Header().Set("X-Mbx-Used-Weight-1m", "42")
+		fmt.Fprint(w, `{"canTrade":true,"balances":[]}`)
+	}))
+	defer server.Close()
+
+	pool := NewClientPool(BaseURL(server.URL), WeightLimit(1000))
+
+	for _, label := range []string{"desk-b", "desk-a"} {
+		_, err := pool.Add(label, APIKey(label), APISecret("secret"))
+		if err != nil {
+			t.Fatalf("Add failed: %s", err.Error())
+		}
+	}
+
+	if _, err := pool.Add("desk-a"); err == nil {
+		t.Errorf("Add accepted a duplicate label")
+	}
+
+	// An account without credentials will fail.
+	_, _ = pool.Add("broken")
+
+	if labels := fmt.Sprint(pool.Labels()); labels != "[broken desk-a desk-b]" {
+		t.Errorf("got labels %s", labels)
+	}
+
+	errs := pool.Each(func(label string, c *Client) error {
+		info, err := c.AccountInfo()
+		if err == nil && !info.CanTrade {
+			err = errors.New("can't trade")
+		}
+
+		return err
+	})
+
+	if len(errs) != 1 || errs["broken"] == nil {
+		t.Errorf("got errors %v", errs)
+	}
+
+	if !keys["desk-a"] || !keys["desk-b"] || len(keys) != 2 {
+		t.Errorf("got requests with keys %v", keys)
+	}
+
+	a, _ := pool.Client("desk-a")
+	b, _ := pool.Client("desk-b")
+	if a.weight != b.weight || a.weight.usedWeight() != 42 || a.weight.limit != 1000 {
+		t.Errorf("weight limit not shared")
+	}
+
+	pool.Remove("broken")
+	if _, found := pool.Client("broken"); found {
+		t.Errorf("Remove left the account")
+	}
+}
@@ -0,0 +1,68 @@
+package binance
+
+// CoinNetwork describes deposits and withdrawals of a coin on a single
+// network.
+type CoinNetwork struct {
+	Network                 string `json:"network"` // FIXME: type
+	Coin                    string `json:"coin"`    // FIXME: type
+	Name                    string `json:"name"`
+	IsDefault               bool   `json:"isDefault"`
+	DepositEnable           bool   `json:"depositEnable"`
+	WithdrawEnable          bool   `json:"withdrawEnable"`
+	DepositDesc             string `json:"depositDesc"`
+	WithdrawDesc            string `json:"withdrawDesc"`
+	SpecialTips             string `json:"specialTips"`
+	MinConfirm              int    `json:"minConfirm"`
+	UnlockConfirm           int    `json:"unLockConfirm"`
+	WithdrawFee             Value  `json:"withdrawFee"`
+	WithdrawMin             Value  `json:"withdrawMin"`
+	WithdrawMax             Value  `json:"withdrawMax"`
+	WithdrawIntegerMultiple Value  `json:"withdrawIntegerMultiple"`
+	AddressRegex            string `json:"addressRegex"`
+	MemoRegex               string `json:"memoRegex"`
+	SameAddress             bool   `json:"sameAddress"`
+	Busy                    bool   `json:"busy"`
+	ContractAddress         string `json:"contractAddress"`
+}
+
+// CoinInfo describes a coin and the networks it can be deposited and
+// withdrawn on.
+type CoinInfo struct {
+	Coin              string        `json:"coin"` // FIXME: type
+	Name              string        `json:"name"`
+	DepositAllEnable  bool          `json:"depositAllEnable"`
+	WithdrawAllEnable bool          `json:"withdrawAllEnable"`
+	Trading           bool          `json:"trading"`
+	IsLegalMoney      bool          `json:"isLegalMoney"`
+	Free              Value         `json:"free"`
+	Locked            Value         `json:"locked"`
+	Freeze            Value         `json:"freeze"`
+	Withdrawing       Value         `json:"withdrawing"`
+	Ipoing            Value         `json:"ipoing"`
+	Ipoable           Value         `json:"ipoable"`
+	Storage           Value         `json:"storage"`
+	Networks          []CoinNetwork `json:"networkList"`
+}
+
+// Network returns the details of network, if the coin can be moved on it.
+func (c *CoinInfo) Network(network string) (CoinNetwork, bool) {
+	for _, n := range c.Networks {
+		if n.Network == network {
+			return n, true
+		}
+	}
+
+	return CoinNetwork{}, false
+}
+
+// CoinInfo lists all coins available for deposit and withdrawal, along with
+// the balance of the account and the fees and limits of each network.
+func (c *Client) CoinInfo() ([]CoinInfo, error) {
+	var coins []CoinInfo
+	err := c.signedCall(&coins, "GET", "/sapi/v1/capital/config/getall")
+	if err != nil {
+		return nil, err
+	}
+
+	return coins, nil
+}
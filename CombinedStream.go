@@ -12,16 +12,20 @@ type CombinedStream struct {
 	*websocket.Conn
 }
 
-// Read a trade from the stream. This will block until a trade is ready.
+// Read an event from the stream. This will block until an event is ready.
+// The all market ticker streams will return []TickerEvent or
+// []MiniTickerEvent, all other streams will return a pointer to the event.
 func (s *CombinedStream) Read() (interface{}, error) {
-	var data = make([]byte, 1024)
+	var data []byte
 
-	n, err := s.Conn.Read(data)
+	// The all market streams can easily carry hundreds of kilobytes, so we
+	// let the websocket package receive the whole message for us.
+	err := websocket.Message.Receive(s.Conn, &data)
 	if err != nil {
 		return nil, err
 	}
 
-	return combinedEvent(data[:n])
+	return combinedEvent(data)
 }
 
 func combinedEvent(data []byte) (interface{}, error) {
@@ -36,7 +40,7 @@ func combinedEvent(data []byte) (interface{}, error) {
 		return nil, err
 	}
 
-	target := p.Stream.Type().iface()
+	target := p.Stream.iface()
 	if target == nil {
 		return nil, fmt.Errorf("unknown stream type: %s", p.Stream.Type())
 	}
@@ -46,6 +50,13 @@ func combinedEvent(data []byte) (interface{}, error) {
 		return nil, err
 	}
 
+	switch t := target.(type) {
+	case *[]TickerEvent:
+		return *t, nil
+	case *[]MiniTickerEvent:
+		return *t, nil
+	}
+
 	return target, nil
 }
 
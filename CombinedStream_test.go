@@ -0,0 +1,124 @@
+package binance
+
+import (
+	"testing"
+)
+
+func TestCombinedEventKline(t *testing.T) {
+	data := `{"stream":"bnbbtc@kline_1m","data":{"e":"kline","E":123456789,"s":"BNBBTC","k":{"t":123400000,"T":123460000,"s":"BNBBTC","i":"1m","f":100,"L":200,"o":"0.0010","c":"0.0020","h":"0.0025","l":"0.0015","v":"1000","n":100,"x":false,"q":"1.0000","V":"500","Q":"0.500","B":"123456"}}}`
+
+	event, err := combinedEvent([]byte(data))
+	if err != nil {
+		t.Fatalf("combinedEvent failed: %s", err.Error())
+	}
+
+	kline, ok := event.(*KlineEvent)
+	if !ok {
+		t.Fatalf("combinedEvent returned %T, expected *KlineEvent", event)
+	}
+
+	if kline.Symbol != "BNBBTC" || kline.Kline.Interval != "1m" || kline.Kline.Closed {
+		t.Errorf("kline decoded wrong: %+v", kline)
+	}
+
+	stick := kline.CandleStick()
+	if stick.Open != "0.0010" || stick.Close != "0.0020" || stick.NumberOfTrades != 100 {
+		t.Errorf("CandleStick() returned %+v", stick)
+	}
+
+	if stick.TakerBuyQuoteAssetVolume != "0.500" {
+		t.Errorf("Wrong taker buy quote volume: %s", stick.TakerBuyQuoteAssetVolume)
+	}
+}
+
+func TestCombinedEventTicker(t *testing.T) {
+	data := `{"stream":"bnbbtc@ticker","data":{"e":"24hrTicker","E":123456789,"s":"BNBBTC","p":"0.0015","P":"250.00","w":"0.0018","x":"0.0009","c":"0.0025","Q":"10","b":"0.0024","B":"10","a":"0.0026","A":"100","o":"0.0010","h":"0.0025","l":"0.0010","v":"10000","q":"18","O":0,"C":86400000,"F":0,"L":18150,"n":18151}}`
+
+	event, err := combinedEvent([]byte(data))
+	if err != nil {
+		t.Fatalf("combinedEvent failed: %s", err.Error())
+	}
+
+	ticker, ok := event.(*TickerEvent)
+	if !ok {
+		t.Fatalf("combinedEvent returned %T, expected *TickerEvent", event)
+	}
+
+	stats := ticker.ChangeStatistics()
+	if stats.LastPrice != "0.0025" || stats.LastQuantity != "10" || stats.PpenPrice != "0.0010" || stats.NumberOfTrades != 18151 {
+		t.Errorf("ChangeStatistics() returned %+v", stats)
+	}
+}
+
+func TestCombinedEventAllMarket(t *testing.T) {
+	cases := []struct {
+		data     string
+		expected string
+	}{
+		{`{"stream":"!ticker@arr","data":[{"e":"24hrTicker","s":"BNBBTC","c":"0.0025"},{"e":"24hrTicker","s":"ETHBTC","c":"0.05"}]}`, "[]binance.TickerEvent"},
+		{`{"stream":"!ticker_1h@arr","data":[{"e":"1hTicker","s":"BNBBTC","c":"0.0025"},{"e":"1hTicker","s":"ETHBTC","c":"0.05"}]}`, "[]binance.TickerEvent"},
+		{`{"stream":"!miniTicker@arr","data":[{"e":"24hrMiniTicker","s":"BNBBTC","c":"0.0025"},{"e":"24hrMiniTicker","s":"ETHBTC","c":"0.05"}]}`, "[]binance.MiniTickerEvent"},
+	}
+
+	for _, c := range cases {
+		event, err := combinedEvent([]byte(c.data))
+		if err != nil {
+			t.Fatalf("combinedEvent failed: %s", err.Error())
+		}
+
+		switch e := event.(type) {
+		case []TickerEvent:
+			if c.expected != "[]binance.TickerEvent" || len(e) != 2 || e[1].LastPrice != "0.05" {
+				t.Errorf("%s decoded as %+v", c.data, e)
+			}
+		case []MiniTickerEvent:
+			if c.expected != "[]binance.MiniTickerEvent" || len(e) != 2 || e[1].ClosePrice != "0.05" {
+				t.Errorf("%s decoded as %+v", c.data, e)
+			}
+		default:
+			t.Errorf("combinedEvent returned %T, expected %s", event, c.expected)
+		}
+	}
+}
+
+func TestCombinedEventDepth(t *testing.T) {
+	data := `{"stream":"bnbbtc@depth5@100ms","data":{"lastUpdateId":160,"bids":[["0.0024","10"],["0.0023","5"]],"asks":[["0.0026","100"]]}}`
+
+	event, err := combinedEvent([]byte(data))
+	if err != nil {
+		t.Fatalf("combinedEvent failed: %s", err.Error())
+	}
+
+	depth, ok := event.(*PartialDepthEvent)
+	if !ok {
+		t.Fatalf("combinedEvent returned %T, expected *PartialDepthEvent", event)
+	}
+
+	book := depth.OrderBook()
+	if book.LastUpdateID != 160 || len(book.Bids) != 2 || len(book.Asks) != 1 {
+		t.Fatalf("OrderBook() returned %+v", book)
+	}
+
+	if book.Bids[1].Price != "0.0023" || book.Bids[1].Quantity != "5" || book.Asks[0].Price != "0.0026" {
+		t.Errorf("OrderBook() returned %+v", book)
+	}
+}
+
+func TestCombinedEventBookTicker(t *testing.T) {
+	data := `{"stream":"bnbusdt@bookTicker","data":{"u":400900217,"s":"BNBUSDT","b":"25.35190000","B":"31.21000000","a":"25.36520000","A":"40.66000000"}}`
+
+	event, err := combinedEvent([]byte(data))
+	if err != nil {
+		t.Fatalf("combinedEvent failed: %s", err.Error())
+	}
+
+	ticker, ok := event.(*BookTickerEvent)
+	if !ok {
+		t.Fatalf("combinedEvent returned %T, expected *BookTickerEvent", event)
+	}
+
+	best := ticker.BestPrice()
+	if best.UpdateID != 400900217 || best.Bid.Price != "25.35190000" || best.Ask.Quantity != "40.66000000" {
+		t.Errorf("Decoded wrong: %+v %+v", ticker, best)
+	}
+}
@@ -0,0 +1,36 @@
+package binance
+
+// CommissionDiscount describes the discount given when paying commission
+// with BNB.
+type CommissionDiscount struct {
+	EnabledForAccount bool   `json:"enabledForAccount"`
+	EnabledForSymbol  bool   `json:"enabledForSymbol"`
+	DiscountAsset     string `json:"discountAsset"` // FIXME: type
+
+	// Discount is the factor applied to the standard commission, so 0.75
+	// means paying 75% of the standard commission.
+	Discount Value `json:"discount"`
+}
+
+// Commission describes the commission rates of the account for a symbol.
+// The rates are added together, the discount only applies to the standard
+// commission.
+type Commission struct {
+	Symbol   Symbol             `json:"symbol"`
+	Standard CommissionRates    `json:"standardCommission"`
+	Special  CommissionRates    `json:"specialCommission"`
+	Tax      CommissionRates    `json:"taxCommission"`
+	Discount CommissionDiscount `json:"discount"`
+}
+
+// Commission returns the current commission rates of the account for
+// symbol.
+func (c *Client) Commission(symbol Symbol) (*Commission, error) {
+	var commission Commission
+	err := c.signedCall(&commission, "GET", "/api/v3/account/commission", param("symbol", symbol.UpperCase()))
+	if err != nil {
+		return nil, err
+	}
+
+	return &commission, nil
+}
@@ -0,0 +1,42 @@
+package binance
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ContingencyType is the type of an order list.
+type ContingencyType string
+
+// An order list is either one-cancels-the-other or one-triggers-the-other.
+// OTOCO lists are reported as OTO.
+const (
+	ContingencyTypeOCO ContingencyType = "OCO"
+	ContingencyTypeOTO ContingencyType = "OTO"
+)
+
+// UnmarshalJSON implements json.Unmarshaler while making sure only enums
+// that we know about end up in a ContingencyType variable.
+func (c *ContingencyType) UnmarshalJSON(data []byte) error {
+	s := ""
+	err := json.Unmarshal(data, &s)
+	if err != nil {
+		return err
+	}
+
+	typ := ContingencyType(s)
+
+	switch typ {
+	case ContingencyTypeOCO, ContingencyTypeOTO:
+		*c = typ
+	default:
+		return fmt.Errorf("%s is not a valid contingency type", s)
+	}
+
+	return nil
+}
+
+// String implement Stringer.
+func (c ContingencyType) String() string {
+	return string(c)
+}
@@ -0,0 +1,114 @@
+package binance
+
+import (
+	"net/url"
+	"sort"
+)
+
+// Deposit describes a deposit to the account.
+type Deposit struct {
+	ID            string        `json:"id"`
+	Amount        Value         `json:"amount"`
+	Coin          string        `json:"coin"`    // FIXME: type
+	Network       string        `json:"network"` // FIXME: type
+	Status        DepositStatus `json:"status"`
+	Address       string        `json:"address"`
+	AddressTag    string        `json:"addressTag"`
+	TxID          string        `json:"txId"`
+	InsertTime    Time          `json:"insertTime"`
+	CompleteTime  Time          `json:"completeTime"`
+	TransferType  int           `json:"transferType"`
+	ConfirmTimes  string        `json:"confirmTimes"`
+	UnlockConfirm int           `json:"unlockConfirm"`
+	WalletType    int           `json:"walletType"`
+}
+
+// Deposits lists deposits of coin, or all coins if coin is empty. Without
+// options, deposits from the last 90 days are returned. You can refine the
+// query with StartTime(), EndTime(), Offset() and Limit(). StartTime() and
+// EndTime() can't be more than 90 days apart, use DepositHistory() for
+// walking longer periods.
+func (c *Client) Deposits(coin string, options ...QueryFunc) ([]Deposit, error) {
+	params := []func(url.Values){newQuery(options).params()}
+
+	if coin != "" {
+		params = append(params, param("coin", coin))
+	}
+
+	var deposits []Deposit
+	err := c.signedCall(&deposits, "GET", "/sapi/v1/capital/deposit/hisrec", params...)
+	if err != nil {
+		return nil, err
+	}
+
+	return deposits, nil
+}
+
+// DepositIterator walks all deposits in a period. It's used like
+// OrderListIterator.
+type DepositIterator struct {
+	client  *Client
+	coin    string
+	pager   *windowPager
+	page    []Deposit
+	deposit Deposit
+	err     error
+}
+
+// DepositHistory returns an iterator walking all deposits of coin, or all
+// coins if coin is empty, from start until end. Deposits are returned in
+// the order they were made.
+func (c *Client) DepositHistory(coin string, start Time, end Time) *DepositIterator {
+	return &DepositIterator{
+		client: c,
+		coin:   coin,
+		pager:  newWindowPager(start, end, walletWindow, 1000),
+	}
+}
+
+// Next advances the iterator to the next deposit. It returns false when
+// there are no more deposits or an error occurred.
+func (it *DepositIterator) Next() bool {
+	for len(it.page) == 0 {
+		if it.err != nil || it.pager.done {
+			return false
+		}
+
+		// Binance returns the newest first, so we need the whole window
+		// before we can return them in order.
+		for windowDone := false; !windowDone; {
+			var page []Deposit
+
+			it.err = retryRateLimited(func() error {
+				var err error
+				page, err = it.client.Deposits(it.coin, it.pager.query()...)
+
+				return err
+			})
+			if it.err != nil {
+				return false
+			}
+
+			it.page = append(it.page, page...)
+			windowDone = it.pager.advance(len(page))
+		}
+
+		sort.SliceStable(it.page, func(i, j int) bool {
+			return it.page[i].InsertTime.Before(it.page[j].InsertTime.Time)
+		})
+	}
+
+	it.deposit, it.page = it.page[0], it.page[1:]
+
+	return true
+}
+
+// Deposit returns the current deposit.
+func (it *DepositIterator) Deposit() Deposit {
+	return it.deposit
+}
+
+// Err returns the error that stopped the iterator, if any.
+func (it *DepositIterator) Err() error {
+	return it.err
+}
@@ -0,0 +1,31 @@
+package binance
+
+import (
+	"net/url"
+)
+
+// DepositAddress is an address for depositing a coin to the account.
+type DepositAddress struct {
+	Address string `json:"address"`
+	Coin    string `json:"coin"` // FIXME: type
+	Tag     string `json:"tag"`
+	URL     string `json:"url"`
+}
+
+// DepositAddress returns the address for depositing coin on network. If
+// network is empty, the default network of the coin is used.
+func (c *Client) DepositAddress(coin string, network string) (*DepositAddress, error) {
+	params := []func(url.Values){param("coin", coin)}
+
+	if network != "" {
+		params = append(params, param("network", network))
+	}
+
+	var address DepositAddress
+	err := c.signedCall(&address, "GET", "/sapi/v1/capital/deposit/address", params...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &address, nil
+}
@@ -0,0 +1,59 @@
+package binance
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// DepositStatus is the status of a deposit.
+type DepositStatus int
+
+// The different states of a deposit.
+const (
+	DepositPending            DepositStatus = 0
+	DepositSuccess            DepositStatus = 1
+	DepositRejected           DepositStatus = 2
+	DepositCreditedNoWithdraw DepositStatus = 6
+	DepositWrong              DepositStatus = 7
+	DepositWaitingUserConfirm DepositStatus = 8
+)
+
+// depositStatusNames are used by String().
+var depositStatusNames = map[DepositStatus]string{
+	DepositPending:            "PENDING",
+	DepositSuccess:            "SUCCESS",
+	DepositRejected:           "REJECTED",
+	DepositCreditedNoWithdraw: "CREDITED_CANNOT_WITHDRAW",
+	DepositWrong:              "WRONG_DEPOSIT",
+	DepositWaitingUserConfirm: "WAITING_USER_CONFIRM",
+}
+
+// UnmarshalJSON implements json.Unmarshaler while making sure only enums
+// that we know about end up in a DepositStatus variable.
+func (d *DepositStatus) UnmarshalJSON(data []byte) error {
+	i := 0
+	err := json.Unmarshal(data, &i)
+	if err != nil {
+		return err
+	}
+
+	status := DepositStatus(i)
+
+	if _, found := depositStatusNames[status]; !found {
+		return fmt.Errorf("%d is not a valid deposit status", i)
+	}
+
+	*d = status
+
+	return nil
+}
+
+// String implement Stringer.
+func (d DepositStatus) String() string {
+	name, found := depositStatusNames[d]
+	if !found {
+		return fmt.Sprintf("DepositStatus(%d)", int(d))
+	}
+
+	return name
+}
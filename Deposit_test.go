@@ -0,0 +1,122 @@
+package binance
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestDepositHistory(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// A deposit every hour for 100 days, served newest first like Binance.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/sapi/v1/capital/deposit/hisrec" {
+			t.Errorf("unexpected request for %s", r.URL.Path)
+			return
+		}
+
+		q := r.URL.Query()
+		limit, _ := strconv.Atoi(q.Get("limit"))
+		offset, _ := strconv.Atoi(q.Get("offset"))
+		startTime, _ := strconv.ParseInt(q.Get("startTime"), 10, 64)
+		endTime, _ := strconv.ParseInt(q.Get("endTime"), 10, 64)
+
+		if endTime-startTime > int64(walletWindow/time.Millisecond) {
+			t.Errorf("time window too long: %s", time.Duration(endTime-startTime)*time.Millisecond)
+		}
+
+		if q.Get("coin") != "BTC" {
+			t.Errorf("coin not set")
+		}
+
+		deposits := []map[string]interface{}{}
+		for i := 100*24 - 1; i >= 0; i-- {
+			ts := start.Add(time.Duration(i)*time.Hour).UnixNano() / int64(time.Millisecond)
+			if ts < startTime || ts > endTime {
+				continue
+			}
+
+			deposits = append(deposits, map[string]interface{}{
+				"id":         strconv.Itoa(i),
+				"coin":       "BTC",
+				"status":     1,
+				"insertTime": ts,
+			})
+		}
+
+		if offset > len(deposits) {
+			offset = len(deposits)
+		}
+
+		deposits = deposits[offset:]
+		if len(deposits) > limit {
+			deposits = deposits[:limit]
+		}
+
+		_ = json.NewEncoder(w).Encode(deposits)
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(APIKey("key"), APISecret("secret"), BaseURL(server.URL))
+
+	it := client.DepositHistory("BTC", FromTime(start), FromTime(start.Add(200*24*time.Hour)))
+
+	n := 0
+	for it.Next() {
+		d := it.Deposit()
+		if d.ID != strconv.Itoa(n) || d.Status != DepositSuccess {
+			t.Fatalf("got deposit %+v, expected %d", d, n)
+		}
+
+		n++
+	}
+
+	if it.Err() != nil || n != 100*24 {
+		t.Errorf("got %d deposits, err %v", n, it.Err())
+	}
+}
+
+func TestWalletJSON(t *testing.T) {
+	data := `{"id":"b6ae22b3aa844210a7041aee7589627c","amount":"8.91000000","transactionFee":"0.004","coin":"USDT","status":6,"address":"0x94df8b352de7f46f64b01d3666bf6e936e44ce60","txId":"0xb5ef8c13b968a406cc62a93a8bd80f9e9a906ef1b3fcf20a2e48573c17659268","applyTime":"2019-10-12 11:12:02","network":"ETH","transferType":0,"withdrawOrderId":"WITHDRAWtest123","info":"The address is not valid. Please confirm with the recipient","confirmNo":3,"walletType":1,"txKey":"","completeTime":"2023-03-23 16:52:41"}`
+
+	var w Withdrawal
+	err := json.Unmarshal([]byte(data), &w)
+	if err != nil {
+		t.Fatalf("Unmarshal failed: %s", err.Error())
+	}
+
+	if w.Status != WithdrawCompleted || !w.Status.Final() || w.ApplyTime.Unix() != 1570878722 || w.TransactionFee != "0.004" {
+		t.Errorf("got withdrawal %+v", w)
+	}
+
+	err = json.Unmarshal([]byte(`{"status":9}`), &w)
+	if err == nil {
+		t.Errorf("unknown status accepted")
+	}
+
+	data = `[{"coin":"BTC","depositAllEnable":true,"free":"0.08074558","freeze":"0.00000000","ipoable":"0.00000000","ipoing":"0.00000000","isLegalMoney":false,"locked":"0.00000000","name":"Bitcoin","networkList":[{"addressRegex":"^(bnb1)[0-9a-z]{38}$","coin":"BTC","depositDesc":"Wallet Maintenance, Deposit Suspended","depositEnable":false,"isDefault":false,"memoRegex":"^[0-9A-Za-z\\-_]{1,120}$","minConfirm":1,"name":"BEP2","network":"BNB","specialTips":"Both a MEMO and an Address are required to successfully deposit your BEP2-BTCB tokens to Binance.","unLockConfirm":0,"withdrawDesc":"Wallet Maintenance, Withdrawal Suspended","withdrawEnable":false,"withdrawFee":"0.00000220","withdrawIntegerMultiple":"0.00000001","withdrawMax":"9999999999.99999999","withdrawMin":"0.00000440","sameAddress":true,"busy":false},{"coin":"BTC","depositEnable":true,"isDefault":true,"minConfirm":1,"name":"BTC","network":"BTC","unLockConfirm":2,"withdrawEnable":true,"withdrawFee":"0.00050000","withdrawMin":"0.00100000","withdrawMax":"750","sameAddress":false,"busy":false}],"storage":"0.00000000","trading":true,"withdrawAllEnable":true,"withdrawing":"0.00000000"}]`
+
+	var coins []CoinInfo
+	err = json.Unmarshal([]byte(data), &coins)
+	if err != nil {
+		t.Fatalf("Unmarshal failed: %s", err.Error())
+	}
+
+	network, found := coins[0].Network("BTC")
+	if !found || !network.WithdrawEnable || network.WithdrawFee != "0.00050000" || network.UnlockConfirm != 2 {
+		t.Errorf("got network %+v %v", network, found)
+	}
+
+	if _, found = coins[0].Network("ETH"); found {
+		t.Errorf("found unknown network")
+	}
+
+	if s := fmt.Sprint(DepositWrong, WithdrawStatus(42)); s != "WRONG_DEPOSIT WithdrawStatus(42)" {
+		t.Errorf("got names %s", s)
+	}
+}
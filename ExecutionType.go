@@ -0,0 +1,60 @@
+package binance
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ExecutionType describes why an execution report was sent.
+type ExecutionType string
+
+// The different execution types.
+const (
+	ExecutionTypeNew             ExecutionType = "NEW"
+	ExecutionTypeCanceled        ExecutionType = "CANCELED"
+	ExecutionTypeReplaced        ExecutionType = "REPLACED"
+	ExecutionTypeRejected        ExecutionType = "REJECTED"
+	ExecutionTypeTrade           ExecutionType = "TRADE"
+	ExecutionTypeExpired         ExecutionType = "EXPIRED"
+	ExecutionTypeTradePrevention ExecutionType = "TRADE_PREVENTION"
+
+	// ExecutionTypeCalculated and ExecutionTypeAmendment are only sent on
+	// futures streams, for liquidations and modified orders.
+	ExecutionTypeCalculated ExecutionType = "CALCULATED"
+	ExecutionTypeAmendment  ExecutionType = "AMENDMENT"
+)
+
+// UnmarshalJSON implements json.Unmarshaler while making sure only enums
+// that we know about end up in a ExecutionType variable.
+func (e *ExecutionType) UnmarshalJSON(data []byte) error {
+	s := ""
+	err := json.Unmarshal(data, &s)
+	if err != nil {
+		return err
+	}
+
+	typ := ExecutionType(s)
+
+	switch typ {
+	case
+		ExecutionTypeNew,
+		ExecutionTypeCanceled,
+		ExecutionTypeReplaced,
+		ExecutionTypeRejected,
+		ExecutionTypeTrade,
+		ExecutionTypeExpired,
+		ExecutionTypeTradePrevention,
+		ExecutionTypeCalculated,
+		ExecutionTypeAmendment:
+		*e = typ
+	default:
+		return fmt.Errorf("%s is not a valid execution type", s)
+	}
+
+	return nil
+}
+
+// String implement Stringer.
+func (e ExecutionType) String() string {
+	return string(e)
+}
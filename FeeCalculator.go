@@ -0,0 +1,146 @@
+package binance
+
+// FeeEstimate is the predicted outcome of an order after commission.
+type FeeEstimate struct {
+	// Quantity is the quantity of the base asset to order.
+	Quantity float64
+
+	// Rate is the commission rate, so 0.001 is 0.1%.
+	Rate float64
+
+	// Commission is the commission paid in CommissionAsset. When paying
+	// with BNB, it's only known if BNBPrice of the calculator is set.
+	Commission      float64
+	CommissionAsset string
+
+	// CommissionQuote is the value of the commission in the quote asset.
+	CommissionQuote float64
+
+	// Net is what is received after commission. Buys receive the base
+	// asset, sells the quote asset.
+	Net float64
+}
+
+// FeeCalculator predicts the commission of orders on a symbol.
+type FeeCalculator struct {
+	Commission *Commission
+	Info       *SymbolInfo
+
+	// BNBPrice is the price of BNB in the quote asset of the symbol. It's
+	// used for predicting commission paid in BNB.
+	BNBPrice float64
+}
+
+// NewFeeCalculator returns a calculator using the current commission rates
+// of the account for symbol.
+func (c *Client) NewFeeCalculator(symbol Symbol) (*FeeCalculator, error) {
+	commission, err := c.Commission(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := c.SymbolInfo(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FeeCalculator{
+		Commission: commission,
+		Info:       info,
+	}, nil
+}
+
+// taker returns true if orders of type typ take liquidity. Limit orders are
+// assumed to rest in the order book.
+func taker(typ OrderType) bool {
+	switch typ {
+	case OrderTypeMarket, OrderTypeStopLoss, OrderTypeTakeProfit:
+		return true
+	}
+
+	return false
+}
+
+// rate returns the combined rate of r for side and typ.
+func (r *CommissionRates) rate(side OrderSide, typ OrderType) float64 {
+	rate := r.Maker.Float64()
+	if taker(typ) {
+		rate = r.Taker.Float64()
+	}
+
+	if side == OrderSideBuy {
+		rate += r.Buyer.Float64()
+	} else {
+		rate += r.Seller.Float64()
+	}
+
+	return rate
+}
+
+// Rate returns the commission rate for an order. bnb decides whether the
+// commission is paid with BNB, if the discount is enabled for the account
+// and symbol.
+func (f *FeeCalculator) Rate(side OrderSide, typ OrderType, bnb bool) float64 {
+	c := f.Commission
+
+	standard := c.Standard.rate(side, typ)
+	if f.discounted(bnb) {
+		standard *= c.Discount.Discount.Float64()
+	}
+
+	return standard + c.Special.rate(side, typ) + c.Tax.rate(side, typ)
+}
+
+// discounted returns true if commission is paid with the discount asset.
+func (f *FeeCalculator) discounted(bnb bool) bool {
+	d := f.Commission.Discount
+
+	return bnb && d.EnabledForAccount && d.EnabledForSymbol
+}
+
+// Estimate predicts the commission and net result of an order for quantity
+// at price. For market orders, price is the expected average price.
+func (f *FeeCalculator) Estimate(side OrderSide, typ OrderType, quantity float64, price float64, bnb bool) FeeEstimate {
+	rate := f.Rate(side, typ, bnb)
+	quote := quantity * price
+
+	e := FeeEstimate{
+		Quantity:        quantity,
+		Rate:            rate,
+		CommissionQuote: quote * rate,
+	}
+
+	switch {
+	case f.discounted(bnb):
+		e.CommissionAsset = f.Commission.Discount.DiscountAsset
+		if f.BNBPrice > 0 {
+			e.Commission = e.CommissionQuote / f.BNBPrice
+		}
+
+		e.Net = quantity
+		if side == OrderSideSell {
+			e.Net = quote
+		}
+
+	case side == OrderSideBuy:
+		e.CommissionAsset = f.Info.BaseAsset
+		e.Commission = quantity * rate
+		e.Net = quantity - e.Commission
+
+	default:
+		e.CommissionAsset = f.Info.QuoteAsset
+		e.Commission = e.CommissionQuote
+		e.Net = quote - e.Commission
+	}
+
+	return e
+}
+
+// Spend predicts a buy spending at most spend of the quote asset at price.
+// The quantity is rounded down to the lot size of the symbol, so it can be
+// used for a limit order.
+func (f *FeeCalculator) Spend(spend float64, price float64, typ OrderType, bnb bool) FeeEstimate {
+	quantity := f.Info.RoundQuantity(spend/price, typ == OrderTypeMarket).Float64()
+
+	return f.Estimate(OrderSideBuy, typ, quantity, price, bnb)
+}
@@ -0,0 +1,68 @@
+package binance
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestFeeCalculator(t *testing.T) {
+	data := `{"symbol":"BTCUSDT","standardCommission":{"maker":"0.00100000","taker":"0.00200000","buyer":"0.00000000","seller":"0.00000000"},"taxCommission":{"maker":"0.00000000","taker":"0.00000000","buyer":"0.00010000","seller":"0.00000000"},"discount":{"enabledForAccount":true,"enabledForSymbol":true,"discountAsset":"BNB","discount":"0.75000000"}}`
+
+	var commission Commission
+	err := json.Unmarshal([]byte(data), &commission)
+	if err != nil {
+		t.Fatalf("Unmarshal failed: %s", err.Error())
+	}
+
+	f := &FeeCalculator{
+		Commission: &commission,
+		Info: &SymbolInfo{Symbol: "BTCUSDT", BaseAsset: "BTC", QuoteAsset: "USDT", Filters: []Filter{
+			{Type: FilterLotSize, MinQuantity: "0.001", MaxQuantity: "100", StepSize: "0.001"},
+		}},
+		BNBPrice: 250,
+	}
+
+	near := func(a float64, b float64) bool {
+		return math.Abs(a-b) < 1e-9
+	}
+
+	cases := []struct {
+		side       OrderSide
+		typ        OrderType
+		bnb        bool
+		rate       float64
+		commission float64
+		asset      string
+		net        float64
+	}{
+		// 2 BTC at 100 USDT.
+		{OrderSideBuy, OrderTypeLimit, false, 0.0011, 0.0022, "BTC", 1.9978},
+		{OrderSideBuy, OrderTypeMarket, false, 0.0021, 0.0042, "BTC", 1.9958},
+		{OrderSideSell, OrderTypeMarket, false, 0.002, 0.4, "USDT", 199.6},
+		{OrderSideSell, OrderTypeLimitMaker, true, 0.00075, 0.15 / 250, "BNB", 200},
+		{OrderSideBuy, OrderTypeMarket, true, 0.0016, 0.32 / 250, "BNB", 2},
+	}
+
+	for i, c := range cases {
+		e := f.Estimate(c.side, c.typ, 2, 100, c.bnb)
+		if !near(e.Rate, c.rate) || !near(e.Commission, c.commission) || e.CommissionAsset != c.asset || !near(e.Net, c.net) {
+			t.Errorf("%d: got %+v", i, e)
+		}
+	}
+
+	e := f.Spend(1000, 300, OrderTypeMarket, false)
+	if e.Quantity != 3.333 || !near(e.Net, 3.333*(1-0.0021)) || e.Quantity*300 > 1000 {
+		t.Errorf("Spend returned %+v", e)
+	}
+
+	commission.Discount.EnabledForSymbol = false
+	if rate := f.Rate(OrderSideSell, OrderTypeLimit, true); rate != 0.001 {
+		t.Errorf("discount used when disabled for symbol: %g", rate)
+	}
+
+	info := AccountInfo{MakerCommission: 15, TakerCommission: 20}
+	if info.MakerRate() != 0.0015 || info.TakerRate() != 0.002 {
+		t.Errorf("got rates %g %g", info.MakerRate(), info.TakerRate())
+	}
+}
@@ -0,0 +1,11 @@
+package binance
+
+// Fill is a single fill of an order as returned when submitting an order
+// with OrderResponseFull.
+type Fill struct {
+	Price           Value  `json:"price"`
+	Quantity        Value  `json:"qty"`
+	Commission      Value  `json:"commission"`
+	CommissionAsset string `json:"commissionAsset"` // FIXME: type
+	TradeID         int64  `json:"tradeId"`
+}
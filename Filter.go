@@ -0,0 +1,163 @@
+package binance
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// The filter types acted on by this package.
+const (
+	FilterPrice         = "PRICE_FILTER"
+	FilterLotSize       = "LOT_SIZE"
+	FilterMarketLotSize = "MARKET_LOT_SIZE"
+	FilterMinNotional   = "MIN_NOTIONAL"
+	FilterNotional      = "NOTIONAL"
+)
+
+// Filter is a trading rule for a symbol. Only the fields relevant for Type
+// are set.
+type Filter struct {
+	Type string `json:"filterType"` // FIXME: type
+
+	// PRICE_FILTER.
+	MinPrice Value `json:"minPrice"`
+	MaxPrice Value `json:"maxPrice"`
+	TickSize Value `json:"tickSize"`
+
+	// LOT_SIZE and MARKET_LOT_SIZE.
+	MinQuantity Value `json:"minQty"`
+	MaxQuantity Value `json:"maxQty"`
+	StepSize    Value `json:"stepSize"`
+
+	// MIN_NOTIONAL and NOTIONAL.
+	MinNotional Value `json:"minNotional"`
+	MaxNotional Value `json:"maxNotional"`
+}
+
+// Filter returns the filter of type typ, if the symbol has one.
+func (s *SymbolInfo) Filter(typ string) (Filter, bool) {
+	for _, f := range s.Filters {
+		if f.Type == typ {
+			return f, true
+		}
+	}
+
+	return Filter{}, false
+}
+
+// lotSize returns the filter limiting quantities for market or limit orders.
+// The market lot size filter can have a zero step size, in that case the
+// regular lot size applies.
+func (s *SymbolInfo) lotSize(market bool) (Filter, bool) {
+	if market {
+		f, found := s.Filter(FilterMarketLotSize)
+		if found && f.StepSize.Float64() > 0 {
+			return f, true
+		}
+	}
+
+	return s.Filter(FilterLotSize)
+}
+
+// MaxQuantity returns the largest quantity allowed for market or limit
+// orders, or an empty value if there's no limit. Market orders are limited
+// by the market lot size filter, even when it has no step size.
+func (s *SymbolInfo) MaxQuantity(market bool) Value {
+	max := zeroValue
+
+	filters := []string{FilterLotSize}
+	if market {
+		filters = append(filters, FilterMarketLotSize)
+	}
+
+	for _, typ := range filters {
+		f, found := s.Filter(typ)
+		if !found || f.MaxQuantity.Float64() <= 0 {
+			continue
+		}
+
+		if max == zeroValue || f.MaxQuantity.Float64() < max.Float64() {
+			max = f.MaxQuantity
+		}
+	}
+
+	return max
+}
+
+// roundDown will round v down to a multiple of step, and format it with the
+// precision of step.
+func roundDown(v float64, step Value) Value {
+	s := step.Float64()
+	if s <= 0 {
+		return Value(strconv.FormatFloat(v, 'f', -1, 64))
+	}
+
+	// The small addition absorbs rounding errors like 0.3/0.1 being
+	// 2.9999999999999996.
+	rounded := math.Floor(v/s+1e-9) * s
+
+	decimals := 0
+	if i := strings.IndexByte(string(step), '.'); i >= 0 {
+		decimals = len(strings.TrimRight(string(step)[i+1:], "0"))
+	}
+
+	return Value(strconv.FormatFloat(rounded, 'f', decimals, 64))
+}
+
+// RoundQuantity rounds quantity down to the step size allowed for the
+// symbol. market decides if the market lot size should be used.
+func (s *SymbolInfo) RoundQuantity(quantity float64, market bool) Value {
+	f, _ := s.lotSize(market)
+
+	return roundDown(quantity, f.StepSize)
+}
+
+// RoundPrice rounds price down to the tick size allowed for the symbol.
+func (s *SymbolInfo) RoundPrice(price float64) Value {
+	f, _ := s.Filter(FilterPrice)
+
+	return roundDown(price, f.TickSize)
+}
+
+// CheckOrder will check quantity and price against the lot size and
+// notional filters of the symbol. price is the limit price, or an estimate
+// for market orders. If price is empty, the notional is not checked.
+func (s *SymbolInfo) CheckOrder(quantity Value, price Value, market bool) error {
+	q := quantity.Float64()
+
+	if f, found := s.lotSize(market); found {
+		if q < f.MinQuantity.Float64() {
+			return fmt.Errorf("quantity %s is below the minimum of %s", quantity, f.MinQuantity)
+		}
+
+	}
+
+	if max := s.MaxQuantity(market); max != zeroValue && q > max.Float64() {
+		return fmt.Errorf("quantity %s is above the maximum of %s", quantity, max)
+	}
+
+	if price == zeroValue {
+		return nil
+	}
+
+	notional := q * price.Float64()
+
+	for _, typ := range []string{FilterMinNotional, FilterNotional} {
+		f, found := s.Filter(typ)
+		if !found {
+			continue
+		}
+
+		if notional < f.MinNotional.Float64() {
+			return fmt.Errorf("notional %g is below the minimum of %s", notional, f.MinNotional)
+		}
+
+		if max := f.MaxNotional.Float64(); max > 0 && notional > max {
+			return fmt.Errorf("notional %g is above the maximum of %s", notional, f.MaxNotional)
+		}
+	}
+
+	return nil
+}
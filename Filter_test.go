@@ -0,0 +1,57 @@
+package binance
+
+import (
+	"testing"
+)
+
+func TestSymbolInfoFilters(t *testing.T) {
+	info := &SymbolInfo{
+		Filters: []Filter{
+			{Type: FilterPrice, MinPrice: "0.01", MaxPrice: "1000000", TickSize: "0.01000000"},
+			{Type: FilterLotSize, MinQuantity: "0.001", MaxQuantity: "100", StepSize: "0.00100000"},
+			{Type: FilterMarketLotSize, MinQuantity: "0", MaxQuantity: "50", StepSize: "0"},
+			{Type: FilterNotional, MinNotional: "5", MaxNotional: "9000000"},
+		},
+	}
+
+	if q := info.RoundQuantity(1.23456, false); q != "1.234" {
+		t.Errorf("RoundQuantity returned %s", q)
+	}
+
+	// The market lot size has no step, the regular lot size applies.
+	if q := info.RoundQuantity(0.3, true); q != "0.300" {
+		t.Errorf("RoundQuantity returned %s", q)
+	}
+
+	if p := info.RoundPrice(123.456); p != "123.45" {
+		t.Errorf("RoundPrice returned %s", p)
+	}
+
+	cases := []struct {
+		quantity Value
+		price    Value
+		valid    bool
+	}{
+		{"1", "100", true},
+		{"0.0001", "100", false},
+		{"101", "100", false},
+		{"0.01", "100", false},
+		{"0.01", "", true},
+	}
+
+	for i, c := range cases {
+		err := info.CheckOrder(c.quantity, c.price, false)
+		if (err == nil) != c.valid {
+			t.Errorf("case %d: CheckOrder returned %v", i, err)
+		}
+	}
+
+	// Market orders are limited by the market lot size.
+	if max := info.MaxQuantity(true); max != "50" {
+		t.Errorf("MaxQuantity returned %s", max)
+	}
+
+	if err := info.CheckOrder("60", "", true); err == nil {
+		t.Errorf("CheckOrder accepted a market order above the maximum")
+	}
+}
@@ -0,0 +1,291 @@
+package binance
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// futuresMarket describes where the API of a futures market lives.
+type futuresMarket struct {
+	baseURL       string
+	streamBaseURL string
+	prefix        string
+
+	// positionVersion is the version of the positionRisk endpoint.
+	positionVersion int
+}
+
+// usdmFutures is the market for USDⓈ-margined futures.
+var usdmFutures = futuresMarket{
+	baseURL:         "https://fapi.binance.com",
+	streamBaseURL:   "wss://fstream.binance.com",
+	prefix:          "/fapi",
+	positionVersion: 2,
+}
+
+// coinmFutures is the market for coin-margined futures.
+var coinmFutures = futuresMarket{
+	baseURL:         "https://dapi.binance.com",
+	streamBaseURL:   "wss://dstream.binance.com",
+	prefix:          "/dapi",
+	positionVersion: 1,
+}
+
+// Futures is a client for a futures market. It shares the transport,
+// signing and rate limiting of Client, but futures have their own weight
+// limit, so a Futures client never shares the limiter of a spot client.
+//
+// USDⓈ-margined and coin-margined futures use the same client, orders and
+// positions. Code placing orders works for both, as long as quantities are
+// computed using the contract info, see FuturesSymbolInfo.Notional() and
+// FuturesSymbolInfo.QuantityForQuote().
+type Futures struct {
+	client *Client
+	market futuresMarket
+}
+
+// NewUSDMFutures returns a client for USDⓈ-margined futures. options are the
+// same as for NewClient(), BaseURL() and StreamBaseURL() can be used for
+// pointing the client at the testnet.
+func NewUSDMFutures(options ...func(*Client)) (*Futures, error) {
+	return newFutures(usdmFutures, options)
+}
+
+// NewCoinMFutures returns a client for coin-margined futures. Quantities are
+// a number of contracts, and margin and profit are in the base asset.
+func NewCoinMFutures(options ...func(*Client)) (*Futures, error) {
+	return newFutures(coinmFutures, options)
+}
+
+func newFutures(market futuresMarket, options []func(*Client)) (*Futures, error) {
+	all := []func(*Client){
+		BaseURL(market.baseURL),
+		StreamBaseURL(market.streamBaseURL),
+	}
+
+	client, err := NewClient(append(all, options...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Futures{
+		client: client,
+		market: market,
+	}, nil
+}
+
+// uri returns the URI of endpoint in version of the API.
+func (f *Futures) uri(version int, endpoint string) string {
+	return fmt.Sprintf("%s/v%d/%s", f.market.prefix, version, endpoint)
+}
+
+// UsedWeight will return the total weight used in the present minute.
+func (f *Futures) UsedWeight() int {
+	return f.client.UsedWeight()
+}
+
+// FuturesSymbolInfo describes a futures contract.
+type FuturesSymbolInfo struct {
+	Symbol                Symbol             `json:"symbol"`
+	Pair                  Symbol             `json:"pair"`
+	ContractType          string             `json:"contractType"` // FIXME: type
+	DeliveryDate          Time               `json:"deliveryDate"`
+	OnboardDate           Time               `json:"onboardDate"`
+	Status                string             `json:"status"`      // FIXME: type
+	BaseAsset             string             `json:"baseAsset"`   // FIXME: type
+	QuoteAsset            string             `json:"quoteAsset"`  // FIXME: type
+	MarginAsset           string             `json:"marginAsset"` // FIXME: type
+	PricePrecision        int                `json:"pricePrecision"`
+	QuantityPrecision     int                `json:"quantityPrecision"`
+	MaintMarginPercent    Value              `json:"maintMarginPercent"`
+	RequiredMarginPercent Value              `json:"requiredMarginPercent"`
+	OrderTypes            []FuturesOrderType `json:"orderTypes"`
+	TimeInForce           []TimeInForce      `json:"timeInForce"`
+	Filters               []Filter           `json:"filters"`
+
+	// ContractSize is the value of a contract in the quote asset. It's only
+	// set for coin-margined contracts.
+	ContractSize int `json:"contractSize"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Coin-margined futures report
+// the status as contractStatus.
+func (s *FuturesSymbolInfo) UnmarshalJSON(data []byte) error {
+	type info FuturesSymbolInfo
+
+	var proxy struct {
+		info
+		ContractStatus string `json:"contractStatus"`
+	}
+
+	err := json.Unmarshal(data, &proxy)
+	if err != nil {
+		return err
+	}
+
+	*s = FuturesSymbolInfo(proxy.info)
+
+	if s.Status == "" {
+		s.Status = proxy.ContractStatus
+	}
+
+	return nil
+}
+
+// Filter returns the filter of type typ, if the contract has one.
+func (s *FuturesSymbolInfo) Filter(typ string) (Filter, bool) {
+	for _, f := range s.Filters {
+		if f.Type == typ {
+			return f, true
+		}
+	}
+
+	return Filter{}, false
+}
+
+// FuturesExchangeInfo describes the trading rules of a futures market.
+type FuturesExchangeInfo struct {
+	Timezone   string              `json:"timezone"`
+	ServerTime Time                `json:"serverTime"`
+	RateLimits []RateLimit         `json:"rateLimits"`
+	Symbols    []FuturesSymbolInfo `json:"symbols"`
+}
+
+// Symbol returns the contract info for symbol.
+func (i *FuturesExchangeInfo) Symbol(symbol Symbol) (*FuturesSymbolInfo, bool) {
+	for n := range i.Symbols {
+		if i.Symbols[n].Symbol.UpperCase() == symbol.UpperCase() {
+			return &i.Symbols[n], true
+		}
+	}
+
+	return nil, false
+}
+
+// ExchangeInfo returns the current trading rules and contracts.
+func (f *Futures) ExchangeInfo() (*FuturesExchangeInfo, error) {
+	info := &FuturesExchangeInfo{}
+
+	err := f.client.publicGet(info, f.uri(1, "exchangeInfo"))
+	if err != nil {
+		return nil, err
+	}
+
+	return info, nil
+}
+
+// OrderBook will return the current order book for symbol.
+func (f *Futures) OrderBook(symbol Symbol, limit int) (*OrderBook, error) {
+	proxy := &orderBookProxy{}
+
+	err := f.client.publicGet(proxy, f.uri(1, "depth"),
+		param("symbol", symbol.UpperCase()),
+		param("limit", limit),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return proxy.real()
+}
+
+// CandleStick returns Kline/candlestick bars for symbol. You can refine the
+// query with Limit(), StartTime() and EndTime().
+func (f *Futures) CandleStick(symbol Symbol, interval string, options ...QueryFunc) ([]CandleStick, error) {
+	var proxy []candleStickProxy
+
+	err := f.client.publicGet(&proxy, f.uri(1, "klines"),
+		param("symbol", symbol.UpperCase()),
+		param("interval", interval),
+		newQuery(options).params(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return candleSticks(proxy)
+}
+
+// MarkPrice is the mark price and funding of a perpetual contract.
+type MarkPrice struct {
+	Symbol               Symbol `json:"symbol"`
+	Pair                 Symbol `json:"pair"`
+	MarkPrice            Value  `json:"markPrice"`
+	IndexPrice           Value  `json:"indexPrice"`
+	EstimatedSettlePrice Value  `json:"estimatedSettlePrice"`
+	LastFundingRate      Value  `json:"lastFundingRate"`
+	InterestRate         Value  `json:"interestRate"`
+	NextFundingTime      Time   `json:"nextFundingTime"`
+	Time                 Time   `json:"time"`
+}
+
+// MarkPrices returns the mark price of all contracts.
+func (f *Futures) MarkPrices() ([]MarkPrice, error) {
+	return f.markPrices()
+}
+
+// MarkPrice returns the mark price of symbol.
+func (f *Futures) MarkPrice(symbol Symbol) (*MarkPrice, error) {
+	prices, err := f.markPrices(param("symbol", symbol.UpperCase()))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(prices) != 1 {
+		return nil, fmt.Errorf("got %d mark prices for %s", len(prices), symbol)
+	}
+
+	return &prices[0], nil
+}
+
+// markPrices returns mark prices. Binance answers with a single object when
+// asked for a single USDⓈ-margined contract, and a list otherwise.
+func (f *Futures) markPrices(params ...func(url.Values)) ([]MarkPrice, error) {
+	var raw json.RawMessage
+
+	err := f.client.publicGet(&raw, f.uri(1, "premiumIndex"), params...)
+	if err != nil {
+		return nil, err
+	}
+
+	var prices []MarkPrice
+
+	if len(raw) > 0 && raw[0] == '{' {
+		prices = make([]MarkPrice, 1)
+		err = json.Unmarshal(raw, &prices[0])
+	} else {
+		err = json.Unmarshal(raw, &prices)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return prices, nil
+}
+
+// FundingRate is a funding rate applied to a perpetual contract.
+type FundingRate struct {
+	Symbol      Symbol `json:"symbol"`
+	FundingRate Value  `json:"fundingRate"`
+	FundingTime Time   `json:"fundingTime"`
+	MarkPrice   Value  `json:"markPrice"`
+}
+
+// FundingRates returns the funding rate history of symbol. Without options,
+// the 100 most recent rates are returned. You can refine the query with
+// StartTime(), EndTime() and Limit().
+func (f *Futures) FundingRates(symbol Symbol, options ...QueryFunc) ([]FundingRate, error) {
+	var rates []FundingRate
+
+	err := f.client.publicGet(&rates, f.uri(1, "fundingRate"),
+		param("symbol", symbol.UpperCase()),
+		newQuery(options).params(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return rates, nil
+}
@@ -0,0 +1,108 @@
+package binance
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// deliveryLayout is the format of the expiry in delivery contract symbols,
+// like BTCUSD_240628.
+const deliveryLayout = "060102"
+
+// perpetualSuffix ends the symbols of coin-margined perpetual contracts.
+const perpetualSuffix = "_PERP"
+
+// ContractExpiry returns the delivery date of the delivery contract symbol,
+// like BTCUSD_240628. It returns false for perpetual contracts.
+func ContractExpiry(symbol Symbol) (time.Time, bool) {
+	s := symbol.UpperCase()
+
+	i := strings.LastIndex(s, "_")
+	if i < 0 || s[i:] == perpetualSuffix {
+		return time.Time{}, false
+	}
+
+	expiry, err := time.ParseInLocation(deliveryLayout, s[i+1:], time.UTC)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return expiry, true
+}
+
+// DeliverySymbol returns the symbol of the delivery contract on pair
+// expiring at expiry.
+func DeliverySymbol(pair Symbol, expiry time.Time) Symbol {
+	return Symbol(pair.UpperCase() + "_" + expiry.UTC().Format(deliveryLayout))
+}
+
+// Perpetual returns true if the contract never expires.
+func (s *FuturesSymbolInfo) Perpetual() bool {
+	return s.ContractType == "PERPETUAL"
+}
+
+// Inverse returns true for coin-margined contracts, where quantities are a
+// number of contracts worth ContractSize of the quote asset, and margin and
+// profit are in the base asset.
+func (s *FuturesSymbolInfo) Inverse() bool {
+	return s.MarginAsset != "" && s.MarginAsset == s.BaseAsset
+}
+
+// Notional returns the value of quantity at price in the margin asset.
+func (s *FuturesSymbolInfo) Notional(quantity float64, price float64) float64 {
+	if s.Inverse() {
+		return quantity * float64(s.ContractSize) / price
+	}
+
+	return quantity * price
+}
+
+// PnL returns the profit in the margin asset of a position of quantity
+// opened at entry and closed at exit. quantity is negative for short
+// positions. For coin-margined contracts the profit is in the base asset,
+// so a long position gains less for each step up in price.
+func (s *FuturesSymbolInfo) PnL(quantity float64, entry float64, exit float64) float64 {
+	if s.Inverse() {
+		return quantity * float64(s.ContractSize) * (1/entry - 1/exit)
+	}
+
+	return quantity * (exit - entry)
+}
+
+// QuantityForQuote returns the order quantity worth amount of the quote
+// asset at price. For coin-margined contracts it's the number of whole
+// contracts, rounded down, and price doesn't matter.
+func (s *FuturesSymbolInfo) QuantityForQuote(amount float64, price float64) float64 {
+	if s.Inverse() {
+		if s.ContractSize == 0 {
+			return 0
+		}
+
+		return float64(int64(amount / float64(s.ContractSize)))
+	}
+
+	return amount / price
+}
+
+// Contracts returns all contracts on pair, ordered by delivery date. The
+// perpetual contract, if any, is last.
+func (i *FuturesExchangeInfo) Contracts(pair Symbol) []FuturesSymbolInfo {
+	var contracts []FuturesSymbolInfo
+
+	for _, s := range i.Symbols {
+		if s.Pair.UpperCase() == pair.UpperCase() {
+			contracts = append(contracts, s)
+		}
+	}
+
+	sort.SliceStable(contracts, func(a, b int) bool {
+		if contracts[a].Perpetual() != contracts[b].Perpetual() {
+			return contracts[b].Perpetual()
+		}
+
+		return contracts[a].DeliveryDate.Before(contracts[b].DeliveryDate.Time)
+	})
+
+	return contracts
+}
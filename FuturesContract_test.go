@@ -0,0 +1,94 @@
+package binance
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestContractExpiry(t *testing.T) {
+	cases := []struct {
+		symbol   Symbol
+		expected string
+	}{
+		{"BTCUSD_240628", "2024-06-28"},
+		{"btcusdt_241227", "2024-12-27"},
+		{"BTCUSD_PERP", ""},
+		{"BTCUSDT", ""},
+		{"BTCUSD_JUNK", ""},
+	}
+
+	for _, c := range cases {
+		expiry, found := ContractExpiry(c.symbol)
+		got := ""
+		if found {
+			got = expiry.Format("2006-01-02")
+		}
+
+		if got != c.expected {
+			t.Errorf("%s: got expiry '%s', expected '%s'", c.symbol, got, c.expected)
+		}
+	}
+
+	symbol := DeliverySymbol("btcusd", time.Date(2024, 6, 28, 8, 0, 0, 0, time.UTC))
+	if symbol != "BTCUSD_240628" {
+		t.Errorf("DeliverySymbol returned %s", symbol)
+	}
+}
+
+func TestFuturesSymbolInfoPnL(t *testing.T) {
+	linear := &FuturesSymbolInfo{Symbol: "BTCUSDT", BaseAsset: "BTC", QuoteAsset: "USDT", MarginAsset: "USDT"}
+	inverse := &FuturesSymbolInfo{Symbol: "BTCUSD_PERP", BaseAsset: "BTC", QuoteAsset: "USD", MarginAsset: "BTC", ContractSize: 100}
+
+	cases := []struct {
+		name     string
+		got      float64
+		expected float64
+	}{
+		{"linear long", linear.PnL(0.5, 20000, 22000), 1000},
+		{"linear short", linear.PnL(-0.5, 20000, 22000), -1000},
+		{"linear notional", linear.Notional(0.5, 20000), 10000},
+		{"linear quantity", linear.QuantityForQuote(10000, 20000), 0.5},
+
+		// 100 contracts of 100 USD is 0.5 BTC at 20000, and 0.4545... BTC at
+		// 22000.
+		{"inverse long", inverse.PnL(100, 20000, 22000), 0.5 - 10000.0/22000},
+		{"inverse short", inverse.PnL(-100, 20000, 22000), 10000.0/22000 - 0.5},
+		{"inverse notional", inverse.Notional(100, 20000), 0.5},
+		{"inverse quantity", inverse.QuantityForQuote(10050, 20000), 100},
+	}
+
+	for _, c := range cases {
+		if math.Abs(c.got-c.expected) > 1e-12 {
+			t.Errorf("%s: got %g, expected %g", c.name, c.got, c.expected)
+		}
+	}
+
+	if linear.Inverse() || !inverse.Inverse() {
+		t.Errorf("Inverse() is wrong")
+	}
+}
+
+func TestFuturesExchangeInfoContracts(t *testing.T) {
+	day := func(d int) Time {
+		return Time{time.Date(2024, 6, d, 8, 0, 0, 0, time.UTC)}
+	}
+
+	info := &FuturesExchangeInfo{Symbols: []FuturesSymbolInfo{
+		{Symbol: "BTCUSD_PERP", Pair: "BTCUSD", ContractType: "PERPETUAL", DeliveryDate: day(1)},
+		{Symbol: "BTCUSD_240628", Pair: "BTCUSD", ContractType: "CURRENT_QUARTER", DeliveryDate: day(28)},
+		{Symbol: "ETHUSD_PERP", Pair: "ETHUSD", ContractType: "PERPETUAL"},
+		{Symbol: "BTCUSD_240614", Pair: "BTCUSD", ContractType: "CURRENT_MONTH", DeliveryDate: day(14)},
+	}}
+
+	contracts := info.Contracts("btcusd")
+
+	var got []Symbol
+	for _, c := range contracts {
+		got = append(got, c.Symbol)
+	}
+
+	if len(got) != 3 || got[0] != "BTCUSD_240614" || got[1] != "BTCUSD_240628" || got[2] != "BTCUSD_PERP" {
+		t.Errorf("Contracts returned %v", got)
+	}
+}
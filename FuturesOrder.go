@@ -0,0 +1,292 @@
+package binance
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// FuturesOrder describes an order on a futures market. For coin-margined
+// contracts, Quantity and ExecutedQuantity are a number of contracts, and
+// CumulativeBaseQuantity is set instead of CumulativeQuoteQuantity.
+type FuturesOrder struct {
+	Symbol                  Symbol                  `json:"symbol"`
+	Pair                    Symbol                  `json:"pair"`
+	ID                      int                     `json:"orderId"`
+	ClientOrderID           string                  `json:"clientOrderId"`
+	Price                   Value                   `json:"price"`
+	AveragePrice            Value                   `json:"avgPrice"`
+	Quantity                Value                   `json:"origQty"`
+	ExecutedQuantity        Value                   `json:"executedQty"`
+	CumulativeQuoteQuantity Value                   `json:"cumQuote"`
+	CumulativeBaseQuantity  Value                   `json:"cumBase"`
+	Status                  OrderStatus             `json:"status"`
+	TimeInForce             TimeInForce             `json:"timeInForce"`
+	Type                    FuturesOrderType        `json:"type"`
+	OriginalType            FuturesOrderType        `json:"origType"`
+	Side                    OrderSide               `json:"side"`
+	PositionSide            PositionSide            `json:"positionSide"`
+	StopPrice               Value                   `json:"stopPrice"`
+	WorkingType             WorkingType             `json:"workingType"`
+	PriceProtect            bool                    `json:"priceProtect"`
+	SelfTradePreventionMode SelfTradePreventionMode `json:"selfTradePreventionMode"`
+	Time                    Time                    `json:"time"`
+	Updated                 Time                    `json:"updateTime"`
+
+	// ReduceOnly orders can only reduce the position. It can't be used in
+	// hedge mode, where PositionSide decides the effect of the order.
+	ReduceOnly bool `json:"reduceOnly"`
+
+	// ClosePosition closes the whole position when a STOP_MARKET or
+	// TAKE_PROFIT_MARKET order triggers. Quantity must not be set.
+	ClosePosition bool `json:"closePosition"`
+
+	// ActivationPrice and CallbackRate are used by trailing stop orders.
+	// CallbackRate is in percent, so 1 is 1%.
+	ActivationPrice Value `json:"activatePrice"`
+	CallbackRate    Value `json:"priceRate"`
+}
+
+// futuresOrderTypeFields describes which fields an order type requires.
+var futuresOrderTypeFields = map[FuturesOrderType]struct {
+	price     bool
+	stopPrice bool
+	trailing  bool
+	close     bool
+}{
+	FuturesLimit:              {price: true},
+	FuturesMarket:             {},
+	FuturesStop:               {price: true, stopPrice: true},
+	FuturesStopMarket:         {stopPrice: true, close: true},
+	FuturesTakeProfit:         {price: true, stopPrice: true},
+	FuturesTakeProfitMarket:   {stopPrice: true, close: true},
+	FuturesTrailingStopMarket: {trailing: true},
+}
+
+// Validate checks that o is a complete order, without asking Binance.
+func (o *FuturesOrder) Validate() error {
+	if o.Symbol == zeroSymbol {
+		return fmt.Errorf("order has no symbol")
+	}
+
+	if o.Side != OrderSideBuy && o.Side != OrderSideSell {
+		return fmt.Errorf("'%s' is not a valid order side", o.Side)
+	}
+
+	fields, found := futuresOrderTypeFields[o.Type]
+	if !found {
+		return fmt.Errorf("'%s' is not a valid futures order type", o.Type)
+	}
+
+	if o.ClientOrderID != "" && !validClientOrderID(o.ClientOrderID) {
+		return fmt.Errorf("%s is not a valid client order ID", o.ClientOrderID)
+	}
+
+	values := []struct {
+		name  string
+		value Value
+	}{
+		{"quantity", o.Quantity},
+		{"price", o.Price},
+		{"stop price", o.StopPrice},
+		{"activation price", o.ActivationPrice},
+		{"callback rate", o.CallbackRate},
+	}
+
+	for _, v := range values {
+		if v.value == zeroValue {
+			continue
+		}
+
+		f, err := v.value.Float64Err()
+		if err != nil || f <= 0 {
+			return fmt.Errorf("%s '%s' is not a positive number", v.name, v.value)
+		}
+	}
+
+	if o.ClosePosition {
+		if !fields.close {
+			return fmt.Errorf("%s orders can't close the position", o.Type)
+		}
+
+		if o.Quantity != zeroValue || o.ReduceOnly {
+			return fmt.Errorf("orders closing the position can't have quantity or be reduce only")
+		}
+	} else if o.Quantity == zeroValue {
+		return fmt.Errorf("%s orders require quantity", o.Type)
+	}
+
+	if o.ReduceOnly && (o.PositionSide == PositionSideLong || o.PositionSide == PositionSideShort) {
+		return fmt.Errorf("reduce only can't be used in hedge mode")
+	}
+
+	if fields.price != (o.Price != zeroValue) {
+		return futuresRequiredOrForbidden(o.Type, "price", fields.price)
+	}
+
+	if fields.stopPrice != (o.StopPrice != zeroValue) {
+		return futuresRequiredOrForbidden(o.Type, "stop price", fields.stopPrice)
+	}
+
+	if fields.trailing != (o.CallbackRate != zeroValue) {
+		return futuresRequiredOrForbidden(o.Type, "callback rate", fields.trailing)
+	}
+
+	if !fields.trailing && o.ActivationPrice != zeroValue {
+		return futuresRequiredOrForbidden(o.Type, "activation price", false)
+	}
+
+	return nil
+}
+
+func futuresRequiredOrForbidden(typ FuturesOrderType, field string, required bool) error {
+	if required {
+		return fmt.Errorf("%s orders require %s", typ, field)
+	}
+
+	return fmt.Errorf("%s orders can't have %s", typ, field)
+}
+
+// params returns the parameters used for submitting o.
+func (o *FuturesOrder) params() []func(url.Values) {
+	params := []func(url.Values){
+		param("newOrderRespType", string(OrderResponseResult)),
+		param("symbol", o.Symbol.UpperCase()),
+		param("side", o.Side),
+		param("type", o.Type),
+	}
+
+	if o.PositionSide != zeroPositionSide {
+		params = append(params, param("positionSide", o.PositionSide))
+	}
+
+	if o.Quantity != zeroValue {
+		params = append(params, param("quantity", o.Quantity))
+	}
+
+	if o.TimeInForce != zeroTimeInForceZero {
+		params = append(params, param("timeInForce", o.TimeInForce))
+	}
+
+	if o.Price != zeroValue {
+		params = append(params, param("price", o.Price))
+	}
+
+	if o.StopPrice != zeroValue {
+		params = append(params, param("stopPrice", o.StopPrice))
+	}
+
+	if o.ClientOrderID != "" {
+		params = append(params, param("newClientOrderId", o.ClientOrderID))
+	}
+
+	if o.ReduceOnly {
+		params = append(params, param("reduceOnly", "true"))
+	}
+
+	if o.ClosePosition {
+		params = append(params, param("closePosition", "true"))
+	}
+
+	if o.ActivationPrice != zeroValue {
+		params = append(params, param("activationPrice", o.ActivationPrice))
+	}
+
+	if o.CallbackRate != zeroValue {
+		params = append(params, param("callbackRate", o.CallbackRate))
+	}
+
+	if o.WorkingType != zeroWorkingType {
+		params = append(params, param("workingType", o.WorkingType))
+	}
+
+	if o.PriceProtect {
+		params = append(params, param("priceProtect", "TRUE"))
+	}
+
+	if o.SelfTradePreventionMode != zeroSelfTradePreventionMode {
+		params = append(params, param("selfTradePreventionMode", o.SelfTradePreventionMode))
+	}
+
+	return params
+}
+
+// SubmitOrder will submit order for processing, and update it with the
+// response from Binance. If order.ClientOrderID is empty, a new ID is
+// generated. Unknown executions are handled like Client.SubmitOrder().
+func (f *Futures) SubmitOrder(order *FuturesOrder) error {
+	if order.ClientOrderID == "" {
+		order.ClientOrderID = f.client.NewClientOrderID()
+	}
+
+	err := order.Validate()
+	if err != nil {
+		return err
+	}
+
+	submit := func() error {
+		return f.client.signedCall(order, "POST", f.uri(1, "order"), order.params()...)
+	}
+
+	lookup := func() error {
+		existing, err := f.OrderStatus(order.Symbol, order.ClientOrderID, 0)
+		if err != nil {
+			return err
+		}
+
+		*order = *existing
+
+		return nil
+	}
+
+	return f.client.retrySubmit(submit, lookup)
+}
+
+// OrderStatus queries the status of an order.
+func (f *Futures) OrderStatus(symbol Symbol, clientOrderID string, id int) (*FuturesOrder, error) {
+	return f.orderCall("GET", symbol, clientOrderID, id)
+}
+
+// CancelOrder cancels a live order.
+func (f *Futures) CancelOrder(symbol Symbol, clientOrderID string, id int) (*FuturesOrder, error) {
+	return f.orderCall("DELETE", symbol, clientOrderID, id)
+}
+
+func (f *Futures) orderCall(method string, symbol Symbol, clientOrderID string, id int) (*FuturesOrder, error) {
+	params, err := orderIDParams(Symbol(symbol.UpperCase()), clientOrderID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var order FuturesOrder
+	err = f.client.signedCall(&order, method, f.uri(1, "order"), params...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &order, nil
+}
+
+// OpenOrders lists the currently open orders. symbol can be left empty to
+// list orders on all contracts.
+func (f *Futures) OpenOrders(symbol Symbol) ([]FuturesOrder, error) {
+	params := []func(url.Values){}
+
+	if symbol != zeroSymbol {
+		params = append(params, param("symbol", symbol.UpperCase()))
+	}
+
+	results := make([]FuturesOrder, 0, 100)
+	err := f.client.signedCall(&results, "GET", f.uri(1, "openOrders"), params...)
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// CancelAllOrders cancels all open orders on symbol.
+func (f *Futures) CancelAllOrders(symbol Symbol) error {
+	return f.client.signedCall(nil, "DELETE", f.uri(1, "allOpenOrders"),
+		param("symbol", symbol.UpperCase()),
+	)
+}
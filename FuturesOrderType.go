@@ -0,0 +1,53 @@
+package binance
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// FuturesOrderType is the type of a futures order.
+type FuturesOrderType string
+
+// The different types of futures orders.
+const (
+	FuturesLimit              FuturesOrderType = "LIMIT"
+	FuturesMarket             FuturesOrderType = "MARKET"
+	FuturesStop               FuturesOrderType = "STOP"
+	FuturesStopMarket         FuturesOrderType = "STOP_MARKET"
+	FuturesTakeProfit         FuturesOrderType = "TAKE_PROFIT"
+	FuturesTakeProfitMarket   FuturesOrderType = "TAKE_PROFIT_MARKET"
+	FuturesTrailingStopMarket FuturesOrderType = "TRAILING_STOP_MARKET"
+)
+
+// UnmarshalJSON implements json.Unmarshaler while making sure only enums
+// that we know about end up in a FuturesOrderType variable.
+func (o *FuturesOrderType) UnmarshalJSON(data []byte) error {
+	str := ""
+	err := json.Unmarshal(data, &str)
+	if err != nil {
+		return err
+	}
+
+	typ := FuturesOrderType(str)
+
+	switch typ {
+	case
+		FuturesLimit,
+		FuturesMarket,
+		FuturesStop,
+		FuturesStopMarket,
+		FuturesTakeProfit,
+		FuturesTakeProfitMarket,
+		FuturesTrailingStopMarket:
+		*o = typ
+	default:
+		return fmt.Errorf("%s is not a valid futures order type", str)
+	}
+
+	return nil
+}
+
+// String implement Stringer.
+func (o FuturesOrderType) String() string {
+	return string(o)
+}
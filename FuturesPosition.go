@@ -0,0 +1,156 @@
+package binance
+
+import (
+	"encoding/json"
+	"net/url"
+)
+
+// FuturesPosition is a position in a futures contract.
+type FuturesPosition struct {
+	Symbol           Symbol       `json:"symbol"`
+	PositionSide     PositionSide `json:"positionSide"`
+	Amount           Value        `json:"positionAmt"`
+	EntryPrice       Value        `json:"entryPrice"`
+	BreakEvenPrice   Value        `json:"breakEvenPrice"`
+	MarkPrice        Value        `json:"markPrice"`
+	UnrealizedProfit Value        `json:"unRealizedProfit"`
+	LiquidationPrice Value        `json:"liquidationPrice"`
+	Leverage         Value        `json:"leverage"`
+	MarginType       MarginType   `json:"marginType"`
+	IsolatedMargin   Value        `json:"isolatedMargin"`
+	IsolatedWallet   Value        `json:"isolatedWallet"`
+	Updated          Time         `json:"updateTime"`
+
+	// Notional is the value of the position in the margin asset. That's the
+	// quote asset for USDⓈ-margined contracts and the base asset for
+	// coin-margined contracts.
+	Notional Value `json:"notional"`
+
+	// MaxNotional is the maximum position at the current leverage for
+	// USDⓈ-margined contracts. Coin-margined contracts set MaxQuantity in
+	// contracts instead.
+	MaxNotional Value `json:"maxNotionalValue"`
+	MaxQuantity Value `json:"maxQty"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Coin-margined futures report
+// the notional as notionalValue.
+func (p *FuturesPosition) UnmarshalJSON(data []byte) error {
+	type position FuturesPosition
+
+	var proxy struct {
+		position
+		NotionalValue Value `json:"notionalValue"`
+	}
+
+	err := json.Unmarshal(data, &proxy)
+	if err != nil {
+		return err
+	}
+
+	*p = FuturesPosition(proxy.position)
+
+	if p.Notional == zeroValue {
+		p.Notional = proxy.NotionalValue
+	}
+
+	return nil
+}
+
+// Positions returns the positions of the account. symbol can be left empty
+// for all contracts. In one-way mode, contracts without a position are
+// returned with a zero amount.
+func (f *Futures) Positions(symbol Symbol) ([]FuturesPosition, error) {
+	params := []func(url.Values){}
+
+	if symbol != zeroSymbol {
+		params = append(params, param("symbol", symbol.UpperCase()))
+	}
+
+	var positions []FuturesPosition
+
+	err := f.client.signedCall(&positions, "GET", f.uri(f.market.positionVersion, "positionRisk"), params...)
+	if err != nil {
+		return nil, err
+	}
+
+	return positions, nil
+}
+
+// FuturesLeverage is the leverage of a contract. Like for FuturesPosition,
+// either MaxNotional or MaxQuantity is set.
+type FuturesLeverage struct {
+	Symbol      Symbol `json:"symbol"`
+	Leverage    int    `json:"leverage"`
+	MaxNotional Value  `json:"maxNotionalValue"`
+	MaxQuantity Value  `json:"maxQty"`
+}
+
+// ChangeLeverage sets the initial leverage of symbol.
+func (f *Futures) ChangeLeverage(symbol Symbol, leverage int) (*FuturesLeverage, error) {
+	var result FuturesLeverage
+
+	err := f.client.signedCall(&result, "POST", f.uri(1, "leverage"),
+		param("symbol", symbol.UpperCase()),
+		param("leverage", leverage),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// ChangeMarginType sets the margin type of symbol. It's not an error if the
+// margin type is already set.
+func (f *Futures) ChangeMarginType(symbol Symbol, marginType MarginType) error {
+	err := f.client.signedCall(nil, "POST", f.uri(1, "marginType"),
+		param("symbol", symbol.UpperCase()),
+		param("marginType", marginType),
+	)
+	if e, ok := err.(*APIError); ok && e.Code == ErrorCodeNoNeedToChangeMarginType {
+		return nil
+	}
+
+	return err
+}
+
+// FuturesIncome is a change of the futures wallet, like realized profit,
+// funding fees or commission.
+type FuturesIncome struct {
+	Symbol        Symbol `json:"symbol"`
+	Type          string `json:"incomeType"` // FIXME: type
+	Income        Value  `json:"income"`
+	Asset         string `json:"asset"` // FIXME: type
+	Info          string `json:"info"`
+	Time          Time   `json:"time"`
+	TransactionID int64  `json:"tranId"`
+	TradeID       string `json:"tradeId"`
+}
+
+// Income returns the income history. symbol and incomeType can be left empty
+// for all contracts and types. Without options, the 100 most recent incomes
+// from the last 7 days are returned. You can refine the query with
+// StartTime(), EndTime() and Limit().
+func (f *Futures) Income(symbol Symbol, incomeType string, options ...QueryFunc) ([]FuturesIncome, error) {
+	params := []func(url.Values){
+		newQuery(options).params(),
+	}
+
+	if symbol != zeroSymbol {
+		params = append(params, param("symbol", symbol.UpperCase()))
+	}
+
+	if incomeType != "" {
+		params = append(params, param("incomeType", incomeType))
+	}
+
+	var income []FuturesIncome
+
+	err := f.client.signedCall(&income, "GET", f.uri(1, "income"), params...)
+	if err != nil {
+		return nil, err
+	}
+
+	return income, nil
+}
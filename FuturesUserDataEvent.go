@@ -0,0 +1,218 @@
+package binance
+
+import (
+	"encoding/json"
+)
+
+// FuturesBalance is the balance of an asset in a futures wallet.
+type FuturesBalance struct {
+	Asset              string `json:"a"` // FIXME: type
+	WalletBalance      Value  `json:"wb"`
+	CrossWalletBalance Value  `json:"cw"`
+	BalanceChange      Value  `json:"bc"`
+}
+
+// FuturesPositionUpdate is the new state of a position.
+type FuturesPositionUpdate struct {
+	Symbol              Symbol       `json:"s"`
+	Amount              Value        `json:"pa"`
+	EntryPrice          Value        `json:"ep"`
+	BreakEvenPrice      Value        `json:"bep"`
+	AccumulatedRealized Value        `json:"cr"`
+	UnrealizedProfit    Value        `json:"up"`
+	MarginType          MarginType   `json:"mt"`
+	IsolatedWallet      Value        `json:"iw"`
+	PositionSide        PositionSide `json:"ps"`
+}
+
+// FuturesAccountUpdateEvent is pushed on the futures user data stream when
+// balances or positions change. Only changed balances and positions are
+// included.
+type FuturesAccountUpdateEvent struct {
+	EventType       string `json:"e"`
+	EventTime       Time   `json:"E"`
+	TransactionTime Time   `json:"T"`
+	Update          struct {
+		Reason    string                  `json:"m"` // FIXME: type
+		Balances  []FuturesBalance        `json:"B"`
+		Positions []FuturesPositionUpdate `json:"P"`
+	} `json:"a"`
+}
+
+// FuturesOrderUpdate is the state of an order after an execution. Binance
+// uses upper and lower case versions of the same letters, so all fields
+// must be declared for encoding/json to match them correctly.
+type FuturesOrderUpdate struct {
+	Symbol                   Symbol                  `json:"s"`
+	ClientOrderID            string                  `json:"c"`
+	Side                     OrderSide               `json:"S"`
+	Type                     FuturesOrderType        `json:"o"`
+	TimeInForce              TimeInForce             `json:"f"`
+	Quantity                 Value                   `json:"q"`
+	Price                    Value                   `json:"p"`
+	AveragePrice             Value                   `json:"ap"`
+	StopPrice                Value                   `json:"sp"`
+	ExecutionType            ExecutionType           `json:"x"`
+	Status                   OrderStatus             `json:"X"`
+	OrderID                  int                     `json:"i"`
+	LastExecutedQuantity     Value                   `json:"l"`
+	CumulativeFilledQuantity Value                   `json:"z"`
+	LastExecutedPrice        Value                   `json:"L"`
+	CommissionAsset          string                  `json:"N"` // FIXME: type
+	Commission               Value                   `json:"n"`
+	TradeTime                Time                    `json:"T"`
+	TradeID                  int64                   `json:"t"`
+	BidsNotional             Value                   `json:"b"`
+	AsksNotional             Value                   `json:"a"`
+	Maker                    bool                    `json:"m"`
+	ReduceOnly               bool                    `json:"R"`
+	WorkingType              WorkingType             `json:"wt"`
+	OriginalType             FuturesOrderType        `json:"ot"`
+	PositionSide             PositionSide            `json:"ps"`
+	ClosePosition            bool                    `json:"cp"`
+	ActivationPrice          Value                   `json:"AP"`
+	CallbackRate             Value                   `json:"cr"`
+	PriceProtect             bool                    `json:"pP"`
+	RealizedProfit           Value                   `json:"rp"`
+	SelfTradePreventionMode  SelfTradePreventionMode `json:"V"`
+}
+
+// Order returns the state of the order after the execution described by u.
+func (u *FuturesOrderUpdate) Order() FuturesOrder {
+	return FuturesOrder{
+		Symbol:                  u.Symbol,
+		ID:                      u.OrderID,
+		ClientOrderID:           u.ClientOrderID,
+		Price:                   u.Price,
+		AveragePrice:            u.AveragePrice,
+		Quantity:                u.Quantity,
+		ExecutedQuantity:        u.CumulativeFilledQuantity,
+		Status:                  u.Status,
+		TimeInForce:             u.TimeInForce,
+		Type:                    u.Type,
+		OriginalType:            u.OriginalType,
+		Side:                    u.Side,
+		PositionSide:            u.PositionSide,
+		StopPrice:               u.StopPrice,
+		WorkingType:             u.WorkingType,
+		PriceProtect:            u.PriceProtect,
+		SelfTradePreventionMode: u.SelfTradePreventionMode,
+		Updated:                 u.TradeTime,
+		ReduceOnly:              u.ReduceOnly,
+		ClosePosition:           u.ClosePosition,
+		ActivationPrice:         u.ActivationPrice,
+		CallbackRate:            u.CallbackRate,
+	}
+}
+
+// FuturesOrderUpdateEvent is pushed on the futures user data stream every
+// time an order is created, updated, filled, canceled or expires.
+type FuturesOrderUpdateEvent struct {
+	EventType       string             `json:"e"`
+	EventTime       Time               `json:"E"`
+	TransactionTime Time               `json:"T"`
+	Order           FuturesOrderUpdate `json:"o"`
+}
+
+// FuturesTradeLiteEvent is a short version of FuturesOrderUpdateEvent,
+// pushed faster for trades.
+type FuturesTradeLiteEvent struct {
+	EventType            string    `json:"e"`
+	EventTime            Time      `json:"E"`
+	TransactionTime      Time      `json:"T"`
+	Symbol               Symbol    `json:"s"`
+	Quantity             Value     `json:"q"`
+	Price                Value     `json:"p"`
+	Maker                bool      `json:"m"`
+	ClientOrderID        string    `json:"c"`
+	Side                 OrderSide `json:"S"`
+	LastExecutedPrice    Value     `json:"L"`
+	LastExecutedQuantity Value     `json:"l"`
+	TradeID              int64     `json:"t"`
+	OrderID              int       `json:"i"`
+}
+
+// FuturesMarginCallEvent is pushed on the futures user data stream when
+// positions are close to liquidation.
+type FuturesMarginCallEvent struct {
+	EventType          string `json:"e"`
+	EventTime          Time   `json:"E"`
+	CrossWalletBalance Value  `json:"cw"`
+	Positions          []struct {
+		Symbol            Symbol       `json:"s"`
+		PositionSide      PositionSide `json:"ps"`
+		Amount            Value        `json:"pa"`
+		MarginType        MarginType   `json:"mt"`
+		IsolatedWallet    Value        `json:"iw"`
+		MarkPrice         Value        `json:"mp"`
+		UnrealizedProfit  Value        `json:"up"`
+		MaintenanceMargin Value        `json:"mm"`
+	} `json:"p"`
+}
+
+// FuturesConfigUpdateEvent is pushed on the futures user data stream when
+// the leverage of a contract or the multi-assets mode changes.
+type FuturesConfigUpdateEvent struct {
+	EventType       string `json:"e"`
+	EventTime       Time   `json:"E"`
+	TransactionTime Time   `json:"T"`
+	Leverage        *struct {
+		Symbol   Symbol `json:"s"`
+		Leverage int    `json:"l"`
+	} `json:"ac"`
+	MultiAssets *struct {
+		Enabled bool `json:"j"`
+	} `json:"ai"`
+}
+
+// futuresUserDataEvent will decode an event from the futures user data
+// stream.
+func futuresUserDataEvent(data []byte) (interface{}, error) {
+	var proxy struct {
+		EventType string          `json:"e"`
+		EventTime json.RawMessage `json:"E"`
+	}
+
+	err := json.Unmarshal(data, &proxy)
+	if err != nil {
+		return nil, err
+	}
+
+	var target interface{}
+
+	switch proxy.EventType {
+	case "ACCOUNT_UPDATE":
+		target = new(FuturesAccountUpdateEvent)
+	case "ORDER_TRADE_UPDATE":
+		target = new(FuturesOrderUpdateEvent)
+	case "TRADE_LITE":
+		target = new(FuturesTradeLiteEvent)
+	case "MARGIN_CALL":
+		target = new(FuturesMarginCallEvent)
+	case "ACCOUNT_CONFIG_UPDATE":
+		target = new(FuturesConfigUpdateEvent)
+	case "listenKeyExpired":
+		target = new(ListenKeyExpiredEvent)
+	default:
+		return unknownEvent(proxy.EventType, data)
+	}
+
+	err = json.Unmarshal(data, target)
+	if err != nil {
+		return nil, err
+	}
+
+	return target, nil
+}
+
+// UserDataStream will open a stream of events concerning the futures
+// account. It's managed like Client.UserDataStream(), but Read() returns
+// *FuturesAccountUpdateEvent, *FuturesOrderUpdateEvent,
+// *FuturesTradeLiteEvent, *FuturesMarginCallEvent,
+// *FuturesConfigUpdateEvent, *ListenKeyExpiredEvent, or *UnknownEvent for
+// events like STRATEGY_UPDATE and GRID_UPDATE.
+func (f *Futures) UserDataStream() (*UserDataStream, error) {
+	keys := listenKeys{uri: f.uri(1, "listenKey")}
+
+	return f.client.userDataStream(keys, futuresUserDataEvent)
+}
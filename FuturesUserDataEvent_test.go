@@ -0,0 +1,75 @@
+package binance
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestFuturesUserDataEventOrderUpdate(t *testing.T) {
+	data := `{"e":"ORDER_TRADE_UPDATE","E":1568879465651,"T":1568879465650,"o":{"s":"BTCUSDT","c":"TEST","S":"SELL","o":"TRAILING_STOP_MARKET","f":"GTC","q":"0.001","p":"0","ap":"7103.04","sp":"7103.04","x":"TRADE","X":"FILLED","i":8886774,"l":"0.001","z":"0.001","L":"7103.04","N":"USDT","n":"0.00284122","T":1568879465650,"t":1234,"b":"0","a":"9.91","m":false,"R":true,"wt":"CONTRACT_PRICE","ot":"TRAILING_STOP_MARKET","ps":"BOTH","cp":false,"AP":"7476.89","cr":"5.0","pP":false,"si":0,"ss":0,"rp":"0","V":"EXPIRE_TAKER","pm":"OPPONENT","gtd":0}}`
+
+	event, err := futuresUserDataEvent([]byte(data))
+	if err != nil {
+		t.Fatalf("futuresUserDataEvent failed: %s", err.Error())
+	}
+
+	update, ok := event.(*FuturesOrderUpdateEvent)
+	if !ok {
+		t.Fatalf("futuresUserDataEvent returned %T, expected *FuturesOrderUpdateEvent", event)
+	}
+
+	// Upper and lower case keys must not leak into each other.
+	o := update.Order
+	if o.AveragePrice != "7103.04" || o.ActivationPrice != "7476.89" || o.TradeID != 1234 ||
+		o.LastExecutedQuantity != "0.001" || o.LastExecutedPrice != "7103.04" ||
+		o.Commission != "0.00284122" || o.CommissionAsset != "USDT" || o.Side != OrderSideSell {
+		t.Errorf("Wrong order update decoded: %+v", o)
+	}
+
+	order := o.Order()
+	if order.ID != 8886774 || order.Status != Filled || order.Type != FuturesTrailingStopMarket ||
+		!order.ReduceOnly || order.CallbackRate != "5.0" {
+		t.Errorf("Order() returned %+v", order)
+	}
+}
+
+func TestFuturesUserDataEventTypes(t *testing.T) {
+	cases := []struct {
+		data     string
+		expected string
+	}{
+		{`{"e":"ACCOUNT_UPDATE","E":1564745798939,"T":1564745798938,"a":{"m":"ORDER","B":[{"a":"USDT","wb":"122624.12345678","cw":"100.12345678","bc":"50.12345678"}],"P":[{"s":"BTCUSDT","pa":"0","ep":"0.00000","bep":"0","cr":"200","up":"0","mt":"isolated","iw":"0.00000000","ps":"BOTH"}]}}`, "*binance.FuturesAccountUpdateEvent"},
+		{`{"e":"TRADE_LITE","E":1721895408092,"T":1721895408214,"s":"BTCUSDT","q":"0.001","p":"0","m":false,"c":"z8hcUoOsqEdKMeKPSABslD","S":"BUY","L":"64089.20","l":"0.040","t":109100866,"i":8886774}`, "*binance.FuturesTradeLiteEvent"},
+		{`{"e":"MARGIN_CALL","E":1587727187525,"cw":"3.16812045","p":[{"s":"ETHUSDT","ps":"LONG","pa":"1.327","mt":"CROSSED","iw":"0","mp":"187.17127","up":"-1.166074","mm":"1.614445"}]}`, "*binance.FuturesMarginCallEvent"},
+		{`{"e":"ACCOUNT_CONFIG_UPDATE","E":1611646737479,"T":1611646737476,"ac":{"s":"BTCUSDT","l":25}}`, "*binance.FuturesConfigUpdateEvent"},
+		{`{"e":"listenKeyExpired","E":1576653824250,"listenKey":"key"}`, "*binance.ListenKeyExpiredEvent"},
+		{`{"e":"STRATEGY_UPDATE","T":1669693391891,"E":1669693391892,"su":{"si":176054594,"st":"GRID","ss":"NEW","s":"BTCUSDT","ut":1669693391891,"c":8}}`, "*binance.UnknownEvent"},
+		{`{"e":"ORDER_TRADE_UPDATE","E":`, ""},
+	}
+
+	for _, c := range cases {
+		event, err := futuresUserDataEvent([]byte(c.data))
+		if c.expected == "" {
+			if err == nil {
+				t.Errorf("futuresUserDataEvent did not fail for %s", c.data)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("futuresUserDataEvent failed for %s: %s", c.data, err.Error())
+			continue
+		}
+
+		if got := fmt.Sprintf("%T", event); got != c.expected {
+			t.Errorf("futuresUserDataEvent returned %s, expected %s", got, c.expected)
+		}
+	}
+
+	event, _ := futuresUserDataEvent([]byte(cases[0].data))
+	update := event.(*FuturesAccountUpdateEvent).Update
+	if len(update.Positions) != 1 || update.Positions[0].MarginType != MarginTypeIsolated || update.Balances[0].WalletBalance != "122624.12345678" {
+		t.Errorf("Wrong account update decoded: %+v", update)
+	}
+}
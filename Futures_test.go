@@ -0,0 +1,203 @@
+package binance
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFuturesOrderValidate(t *testing.T) {
+	cases := []struct {
+		order FuturesOrder
+		valid bool
+	}{
+		{FuturesOrder{Symbol: "BTCUSDT", Side: OrderSideBuy, Type: FuturesMarket, Quantity: "1"}, true},
+		{FuturesOrder{Symbol: "BTCUSDT", Side: OrderSideBuy, Type: FuturesMarket}, false},
+		{FuturesOrder{Symbol: "BTCUSDT", Side: OrderSideBuy, Type: FuturesLimit, Quantity: "1"}, false},
+		{FuturesOrder{Symbol: "BTCUSDT", Side: OrderSideBuy, Type: FuturesLimit, Quantity: "1", Price: "100", TimeInForce: GoodTillCrossing}, true},
+		{FuturesOrder{Symbol: "BTCUSDT", Side: OrderSideBuy, Type: FuturesStop, Quantity: "1", Price: "100"}, false},
+		{FuturesOrder{Symbol: "BTCUSDT", Side: OrderSideSell, Type: FuturesStopMarket, StopPrice: "90", ClosePosition: true}, true},
+		{FuturesOrder{Symbol: "BTCUSDT", Side: OrderSideSell, Type: FuturesStopMarket, StopPrice: "90", ClosePosition: true, Quantity: "1"}, false},
+		{FuturesOrder{Symbol: "BTCUSDT", Side: OrderSideSell, Type: FuturesMarket, ClosePosition: true}, false},
+		{FuturesOrder{Symbol: "BTCUSDT", Side: OrderSideSell, Type: FuturesMarket, Quantity: "1", ReduceOnly: true}, true},
+		{FuturesOrder{Symbol: "BTCUSDT", Side: OrderSideSell, Type: FuturesMarket, Quantity: "1", ReduceOnly: true, PositionSide: PositionSideLong}, false},
+		{FuturesOrder{Symbol: "BTCUSDT", Side: OrderSideSell, Type: FuturesTrailingStopMarket, Quantity: "1", CallbackRate: "1", ActivationPrice: "110"}, true},
+		{FuturesOrder{Symbol: "BTCUSDT", Side: OrderSideSell, Type: FuturesTrailingStopMarket, Quantity: "1"}, false},
+		{FuturesOrder{Symbol: "BTCUSDT", Side: OrderSideSell, Type: FuturesMarket, Quantity: "1", ActivationPrice: "110"}, false},
+		{FuturesOrder{Symbol: "BTCUSDT", Side: OrderSideSell, Type: "STOP_LOSS", Quantity: "1"}, false},
+	}
+
+	for i, c := range cases {
+		err := c.order.Validate()
+		if (err == nil) != c.valid {
+			t.Errorf("%d: Validate returned %v", i, err)
+		}
+	}
+}
+
+func TestFutures(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+
+		switch r.Method + " " + r.URL.Path {
+		case "GET /fapi/v1/premiumIndex":
+			if q.Get("symbol") != "" {
+				fmt.Fprint(w, `{"symbol":"BTCUSDT","markPrice":"11793.63104562","lastFundingRate":"0.00038246","nextFundingTime":1597392000000}`)
+				return
+			}
+
+			fmt.Fprint(w, `[{"symbol":"BTCUSDT","markPrice":"11793.63104562"},{"symbol":"ETHUSDT","markPrice":"390.1"}]`)
+
+		case "GET /fapi/v1/fundingRate":
+			fmt.Fprint(w, `[{"symbol":"BTCUSDT","fundingRate":"-0.03750000","fundingTime":1570608000000,"markPrice":"34287.54619963"}]`)
+
+		case "POST /fapi/v1/order":
+			expected := "closePosition=true&newOrderRespType=RESULT&positionSide=LONG&side=SELL&stopPrice=90&symbol=BTCUSDT&type=STOP_MARKET&workingType=MARK_PRICE"
+
+			client := q.Get("newClientOrderId")
+			for _, key := range []string{"newClientOrderId", "timestamp", "signature"} {
+				q.Del(key)
+			}
+
+			if q.Encode() != expected {
+				t.Errorf("got %s, expected %s", q.Encode(), expected)
+			}
+
+			fmt.Fprintf(w, `{"symbol":"BTCUSDT","orderId":22542179,"clientOrderId":"%s","status":"NEW","type":"STOP_MARKET","closePosition":true,"positionSide":"LONG"}`, client)
+
+		case "GET /fapi/v2/positionRisk":
+			fmt.Fprint(w, `[{"symbol":"BTCUSDT","positionAmt":"0.001","entryPrice":"22185.2","leverage":"10","marginType":"cross","isolatedMargin":"0.00000000","positionSide":"BOTH","notional":"21.49"}]`)
+
+		case "POST /fapi/v1/leverage":
+			fmt.Fprintf(w, `{"leverage":%s,"maxNotionalValue":"1000000","symbol":"BTCUSDT"}`, q.Get("leverage"))
+
+		case "POST /fapi/v1/marginType":
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"code":-4046,"msg":"No need to change margin type."}`)
+
+		case "GET /fapi/v1/income":
+			if q.Get("incomeType") != "FUNDING_FEE" || q.Get("limit") != "10" {
+				t.Errorf("got income query %s", r.URL.RawQuery)
+			}
+
+			fmt.Fprint(w, `[{"symbol":"BTCUSDT","incomeType":"FUNDING_FEE","income":"-0.01","asset":"USDT","info":"","time":1570636800000,"tranId":9689322392,"tradeId":""}]`)
+
+		default:
+			t.Errorf("unexpected request for %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	futures, _ := NewUSDMFutures(APIKey("key"), APISecret("secret"), BaseURL(server.URL))
+
+	price, err := futures.MarkPrice("btcusdt")
+	if err != nil || price.MarkPrice != "11793.63104562" || price.NextFundingTime.Unix() != 1597392000 {
+		t.Errorf("MarkPrice returned %+v %v", price, err)
+	}
+
+	prices, err := futures.MarkPrices()
+	if err != nil || len(prices) != 2 {
+		t.Errorf("MarkPrices returned %+v %v", prices, err)
+	}
+
+	rates, err := futures.FundingRates("BTCUSDT")
+	if err != nil || len(rates) != 1 || rates[0].FundingRate != "-0.03750000" {
+		t.Errorf("FundingRates returned %+v %v", rates, err)
+	}
+
+	order := &FuturesOrder{
+		Symbol:        "btcusdt",
+		Side:          OrderSideSell,
+		PositionSide:  PositionSideLong,
+		Type:          FuturesStopMarket,
+		StopPrice:     "90",
+		ClosePosition: true,
+		WorkingType:   WorkingTypeMarkPrice,
+	}
+
+	err = futures.SubmitOrder(order)
+	if err != nil || order.ID != 22542179 || order.Status != New || order.ClientOrderID == "" {
+		t.Errorf("SubmitOrder returned %+v %v", order, err)
+	}
+
+	positions, err := futures.Positions("")
+	if err != nil || len(positions) != 1 || positions[0].MarginType != MarginTypeCrossed || positions[0].Leverage != "10" {
+		t.Errorf("Positions returned %+v %v", positions, err)
+	}
+
+	leverage, err := futures.ChangeLeverage("BTCUSDT", 20)
+	if err != nil || leverage.Leverage != 20 {
+		t.Errorf("ChangeLeverage returned %+v %v", leverage, err)
+	}
+
+	err = futures.ChangeMarginType("BTCUSDT", MarginTypeCrossed)
+	if err != nil {
+		t.Errorf("ChangeMarginType returned %v", err)
+	}
+
+	income, err := futures.Income("", "FUNDING_FEE", Limit(10))
+	if err != nil || len(income) != 1 || income[0].TransactionID != 9689322392 {
+		t.Errorf("Income returned %+v %v", income, err)
+	}
+}
+
+func TestCoinMFutures(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /dapi/v1/exchangeInfo":
+			fmt.Fprint(w, `{"symbols":[{"symbol":"BTCUSD_PERP","pair":"BTCUSD","contractType":"PERPETUAL","contractStatus":"TRADING","contractSize":100,"baseAsset":"BTC","quoteAsset":"USD","marginAsset":"BTC"}]}`)
+
+		case "GET /dapi/v1/premiumIndex":
+			fmt.Fprint(w, `[{"symbol":"BTCUSD_PERP","pair":"BTCUSD","markPrice":"11029.69574559"}]`)
+
+		case "POST /dapi/v1/order":
+			fmt.Fprintf(w, `{"symbol":"BTCUSD_PERP","pair":"BTCUSD","orderId":1,"clientOrderId":"%s","status":"FILLED","origQty":"%s","cumBase":"0.0045"}`,
+				r.URL.Query().Get("newClientOrderId"), r.URL.Query().Get("quantity"))
+
+		case "GET /dapi/v1/positionRisk":
+			fmt.Fprint(w, `[{"symbol":"BTCUSD_PERP","positionAmt":"1","entryPrice":"11000","marginType":"isolated","positionSide":"BOTH","notionalValue":"0.0090","maxQty":"100"}]`)
+
+		default:
+			t.Errorf("unexpected request for %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	futures, _ := NewCoinMFutures(APIKey("key"), APISecret("secret"), BaseURL(server.URL))
+
+	exchange, err := futures.ExchangeInfo()
+	if err != nil {
+		t.Fatalf("ExchangeInfo returned %v", err)
+	}
+
+	info, found := exchange.Symbol("btcusd_perp")
+	if !found || info.Status != "TRADING" || info.ContractSize != 100 || !info.Inverse() {
+		t.Fatalf("ExchangeInfo returned %+v", exchange)
+	}
+
+	price, err := futures.MarkPrice("BTCUSD_PERP")
+	if err != nil || price.MarkPrice != "11029.69574559" {
+		t.Errorf("MarkPrice returned %+v %v", price, err)
+	}
+
+	// 150 USD buys a single contract of 100 USD.
+	quantity := info.QuantityForQuote(150, price.MarkPrice.Float64())
+
+	order := &FuturesOrder{
+		Symbol:   info.Symbol,
+		Side:     OrderSideBuy,
+		Type:     FuturesMarket,
+		Quantity: Value(fmt.Sprint(quantity)),
+	}
+
+	err = futures.SubmitOrder(order)
+	if err != nil || order.Quantity != "1" || order.CumulativeBaseQuantity != "0.0045" || order.Pair != "BTCUSD" {
+		t.Errorf("SubmitOrder returned %+v %v", order, err)
+	}
+
+	positions, err := futures.Positions("")
+	if err != nil || len(positions) != 1 || positions[0].Notional != "0.0090" || positions[0].MaxQuantity != "100" {
+		t.Errorf("Positions returned %+v %v", positions, err)
+	}
+}
@@ -0,0 +1,52 @@
+package binance
+
+// KlineEvent is pushed by Binance every time a candle stick is updated.
+type KlineEvent struct {
+	EventType string `json:"e"`
+	EventTime Time   `json:"E"`
+	Symbol    Symbol `json:"s"`
+	Kline     Kline  `json:"k"`
+}
+
+// Kline is the candle stick carried in a KlineEvent.
+type Kline struct {
+	OpenTime                 Time   `json:"t"`
+	CloseTime                Time   `json:"T"`
+	Symbol                   Symbol `json:"s"`
+	Interval                 string `json:"i"`
+	FirstTradeID             int64  `json:"f"`
+	LastTradeID              int64  `json:"L"`
+	Open                     Value  `json:"o"`
+	Close                    Value  `json:"c"`
+	High                     Value  `json:"h"`
+	Low                      Value  `json:"l"`
+	Volume                   Value  `json:"v"`
+	NumberOfTrades           int    `json:"n"`
+	Closed                   bool   `json:"x"`
+	QuoteAssetVolume         Value  `json:"q"`
+	TakerBuyBaseAssetVolume  Value  `json:"V"`
+	TakerBuyQuoteAssetVolume Value  `json:"Q"`
+}
+
+// CandleStick returns the candle as a CandleStick as known from the REST
+// API.
+func (k *Kline) CandleStick() CandleStick {
+	return CandleStick{
+		OpenTime:                 k.OpenTime,
+		Open:                     k.Open,
+		High:                     k.High,
+		Low:                      k.Low,
+		Close:                    k.Close,
+		Volume:                   k.Volume,
+		CloseTime:                k.CloseTime,
+		QuoteAssetVolume:         k.QuoteAssetVolume,
+		NumberOfTrades:           k.NumberOfTrades,
+		TakerBuyBaseAssetVolume:  k.TakerBuyBaseAssetVolume,
+		TakerBuyQuoteAssetVolume: k.TakerBuyQuoteAssetVolume,
+	}
+}
+
+// CandleStick returns the candle carried by e.
+func (e *KlineEvent) CandleStick() CandleStick {
+	return e.Kline.CandleStick()
+}
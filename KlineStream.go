@@ -0,0 +1,44 @@
+package binance
+
+import (
+	"fmt"
+
+	"golang.org/x/net/websocket"
+)
+
+// KlineStream represents a stream from the kline/candlestick endpoint.
+type KlineStream struct {
+	*websocket.Conn
+}
+
+// Read a kline event from the stream. This will block until an event is
+// ready.
+func (s *KlineStream) Read() (*KlineEvent, error) {
+	event := &KlineEvent{}
+
+	err := websocket.JSON.Receive(s.Conn, event)
+	if err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
+
+// KlineStream will open a websocket stream that will stream candle stick
+// updates for symbol. interval is given in the same format as for
+// CandleStick(), for example "1m" or "4h". You can use the Read() method when
+// reading from the stream. You should call Close() when done.
+func (c *Client) KlineStream(symbol Symbol, interval string) (*KlineStream, error) {
+	URL := fmt.Sprintf("%s/ws/%s@kline_%s", c.streamBaseURL, symbol.LowerCase(), interval)
+
+	conn, err := websocket.Dial(URL, "", "http://localhost/")
+	if err != nil {
+		return nil, err
+	}
+
+	stream := &KlineStream{
+		Conn: conn,
+	}
+
+	return stream, nil
+}
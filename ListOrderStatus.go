@@ -0,0 +1,42 @@
+package binance
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ListOrderStatus is the status of the orders in an order list.
+type ListOrderStatus string
+
+// The different states of the orders in an order list.
+const (
+	ListOrderStatusExecuting ListOrderStatus = "EXECUTING"
+	ListOrderStatusAllDone   ListOrderStatus = "ALL_DONE"
+	ListOrderStatusReject    ListOrderStatus = "REJECT"
+)
+
+// UnmarshalJSON implements json.Unmarshaler while making sure only enums
+// that we know about end up in a ListOrderStatus variable.
+func (l *ListOrderStatus) UnmarshalJSON(data []byte) error {
+	s := ""
+	err := json.Unmarshal(data, &s)
+	if err != nil {
+		return err
+	}
+
+	status := ListOrderStatus(s)
+
+	switch status {
+	case ListOrderStatusExecuting, ListOrderStatusAllDone, ListOrderStatusReject:
+		*l = status
+	default:
+		return fmt.Errorf("%s is not a valid list order status", s)
+	}
+
+	return nil
+}
+
+// String implement Stringer.
+func (l ListOrderStatus) String() string {
+	return string(l)
+}
@@ -0,0 +1,47 @@
+package binance
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ListStatusType is the status of an order list as a whole.
+type ListStatusType string
+
+// The different states of an order list.
+const (
+	ListStatusTypeResponse    ListStatusType = "RESPONSE"
+	ListStatusTypeExecStarted ListStatusType = "EXEC_STARTED"
+	ListStatusTypeUpdated     ListStatusType = "UPDATED"
+	ListStatusTypeAllDone     ListStatusType = "ALL_DONE"
+)
+
+// UnmarshalJSON implements json.Unmarshaler while making sure only enums
+// that we know about end up in a ListStatusType variable.
+func (l *ListStatusType) UnmarshalJSON(data []byte) error {
+	s := ""
+	err := json.Unmarshal(data, &s)
+	if err != nil {
+		return err
+	}
+
+	status := ListStatusType(s)
+
+	switch status {
+	case
+		ListStatusTypeResponse,
+		ListStatusTypeExecStarted,
+		ListStatusTypeUpdated,
+		ListStatusTypeAllDone:
+		*l = status
+	default:
+		return fmt.Errorf("%s is not a valid list status type", s)
+	}
+
+	return nil
+}
+
+// String implement Stringer.
+func (l ListStatusType) String() string {
+	return string(l)
+}
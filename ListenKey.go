@@ -0,0 +1,62 @@
+package binance
+
+import (
+	"net/url"
+)
+
+// listenKeys is the endpoint managing listen keys for a kind of user data
+// stream. params are added to all calls, like the symbol of an isolated
+// margin account.
+type listenKeys struct {
+	uri    string
+	params []func(url.Values)
+}
+
+// spotListenKeys manages listen keys for the spot account.
+var spotListenKeys = listenKeys{uri: "/api/v3/userDataStream"}
+
+// create will start a new user data stream and return the listen key.
+func (k listenKeys) create(c *Client) (string, error) {
+	var proxy struct {
+		ListenKey string `json:"listenKey"`
+	}
+
+	err := c.keyedCall(&proxy, "POST", k.uri, k.params...)
+	if err != nil {
+		return "", err
+	}
+
+	return proxy.ListenKey, nil
+}
+
+// keepAlive will extend the validity of listenKey.
+func (k listenKeys) keepAlive(c *Client, listenKey string) error {
+	params := append([]func(url.Values){param("listenKey", listenKey)}, k.params...)
+
+	return c.keyedCall(nil, "PUT", k.uri, params...)
+}
+
+// close will close the user data stream identified by listenKey.
+func (k listenKeys) close(c *Client, listenKey string) error {
+	params := append([]func(url.Values){param("listenKey", listenKey)}, k.params...)
+
+	return c.keyedCall(nil, "DELETE", k.uri, params...)
+}
+
+// CreateListenKey will start a new user data stream and return the listen
+// key identifying it. The stream will close after 60 minutes unless
+// KeepAliveListenKey() is called.
+func (c *Client) CreateListenKey() (string, error) {
+	return spotListenKeys.create(c)
+}
+
+// KeepAliveListenKey will extend the validity of listenKey by 60 minutes.
+// Binance recommends doing this every 30 minutes.
+func (c *Client) KeepAliveListenKey(listenKey string) error {
+	return spotListenKeys.keepAlive(c, listenKey)
+}
+
+// CloseListenKey will close the user data stream identified by listenKey.
+func (c *Client) CloseListenKey(listenKey string) error {
+	return spotListenKeys.close(c, listenKey)
+}
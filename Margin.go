@@ -0,0 +1,278 @@
+package binance
+
+import (
+	"encoding/json"
+	"net/url"
+)
+
+// Margin trades on the cross margin account, or the isolated margin account
+// of a single symbol. Orders, trades and listen keys are the same as for
+// the spot account, and the methods are used like those on Client.
+type Margin struct {
+	client     *Client
+	symbol     Symbol
+	sideEffect SideEffectType
+}
+
+// MarginOption is used for setting optional parameters of margin trading.
+type MarginOption func(*Margin)
+
+// SideEffect sets whether orders submitted borrow or repay automatically.
+// The default is SideEffectNone.
+func SideEffect(sideEffect SideEffectType) MarginOption {
+	return func(m *Margin) {
+		m.sideEffect = sideEffect
+	}
+}
+
+// CrossMargin returns a Margin trading on the cross margin account.
+func (c *Client) CrossMargin(options ...MarginOption) *Margin {
+	return c.margin(zeroSymbol, options)
+}
+
+// IsolatedMargin returns a Margin trading on the isolated margin account of
+// symbol.
+func (c *Client) IsolatedMargin(symbol Symbol, options ...MarginOption) *Margin {
+	return c.margin(Symbol(symbol.UpperCase()), options)
+}
+
+func (c *Client) margin(symbol Symbol, options []MarginOption) *Margin {
+	m := &Margin{
+		client: c,
+		symbol: symbol,
+	}
+
+	for _, option := range options {
+		option(m)
+	}
+
+	return m
+}
+
+// Isolated returns true if m trades on an isolated margin account.
+func (m *Margin) Isolated() bool {
+	return m.symbol != zeroSymbol
+}
+
+// Symbol returns the symbol of the isolated margin account, or the zero
+// symbol for cross margin.
+func (m *Margin) Symbol() Symbol {
+	return m.symbol
+}
+
+// isolated adds the isIsolated parameter for isolated margin.
+func (m *Margin) isolated() func(url.Values) {
+	return func(v url.Values) {
+		if m.Isolated() {
+			param("isIsolated", "TRUE")(v)
+		}
+	}
+}
+
+// symbolOrAccount adds symbol if set, and the parameters identifying the
+// account. For isolated margin, the symbol of the account is used.
+func (m *Margin) symbolOrAccount(symbol Symbol) func(url.Values) {
+	return func(v url.Values) {
+		if m.Isolated() {
+			param("isIsolated", "TRUE")(v)
+			param("symbol", m.symbol)(v)
+
+			return
+		}
+
+		if symbol != zeroSymbol {
+			param("symbol", symbol.UpperCase())(v)
+		}
+	}
+}
+
+// SubmitOrder will submit order to the margin account. It works like
+// Client.SubmitOrder(), including recovering from unknown executions, and
+// sets the side effect of m.
+func (m *Margin) SubmitOrder(order *Order) error {
+	extra := []func(url.Values){m.isolated()}
+
+	if m.sideEffect != zeroSideEffectType {
+		extra = append(extra, param("sideEffectType", m.sideEffect))
+	}
+
+	return m.client.submitRecovering(order, "/sapi/v1/margin/order", m.OrderStatus, extra...)
+}
+
+// OrderStatus queries the status of a margin order.
+func (m *Margin) OrderStatus(symbol Symbol, clientOrderID string, id int) (*Order, error) {
+	return m.orderCall("GET", symbol, clientOrderID, id)
+}
+
+// CancelOrder cancels a live margin order.
+func (m *Margin) CancelOrder(symbol Symbol, clientOrderID string, id int) (*Order, error) {
+	return m.orderCall("DELETE", symbol, clientOrderID, id)
+}
+
+func (m *Margin) orderCall(method string, symbol Symbol, clientOrderID string, id int) (*Order, error) {
+	params, err := orderIDParams(symbol, clientOrderID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var order Order
+	err = m.client.signedCall(&order, method, "/sapi/v1/margin/order", append(params, m.isolated())...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &order, nil
+}
+
+// OpenOrders lists the currently open margin orders. For cross margin,
+// symbol can be left empty to list orders on all symbols.
+func (m *Margin) OpenOrders(symbol Symbol) ([]Order, error) {
+	results := make([]Order, 0, 100)
+	err := m.client.signedCall(&results, "GET", "/sapi/v1/margin/openOrders",
+		m.symbolOrAccount(symbol),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// CancelAllOrders cancels all open margin orders on symbol, including order
+// lists. It works like Client.CancelAllOrders().
+func (m *Margin) CancelAllOrders(symbol Symbol) ([]Order, []OrderList, error) {
+	var results []json.RawMessage
+
+	err := m.client.signedCall(&results, "DELETE", "/sapi/v1/margin/openOrders",
+		param("symbol", symbol.UpperCase()),
+		m.isolated(),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return splitCancelled(results)
+}
+
+// Trades return margin trades for symbol. You can refine the query like
+// Client.MyTrades().
+func (m *Margin) Trades(symbol Symbol, options ...QueryFunc) ([]TradeOrder, error) {
+	var trades []TradeOrder
+
+	err := m.client.signedCall(&trades, "GET", "/sapi/v1/margin/myTrades",
+		param("symbol", symbol.UpperCase()),
+		m.isolated(),
+		newQuery(options).params(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return trades, nil
+}
+
+// Borrow will borrow amount of asset, and return the transaction ID.
+func (m *Margin) Borrow(asset string, amount Value) (int64, error) {
+	return m.borrowRepay("BORROW", asset, amount)
+}
+
+// Repay will repay amount of asset, and return the transaction ID.
+// Interest is repaid before the principal.
+func (m *Margin) Repay(asset string, amount Value) (int64, error) {
+	return m.borrowRepay("REPAY", asset, amount)
+}
+
+func (m *Margin) borrowRepay(typ string, asset string, amount Value) (int64, error) {
+	params := []func(url.Values){
+		param("asset", asset),
+		param("amount", amount),
+		param("type", typ),
+	}
+
+	if m.Isolated() {
+		params = append(params, param("isIsolated", "TRUE"), param("symbol", m.symbol))
+	} else {
+		params = append(params, param("isIsolated", "FALSE"))
+	}
+
+	var result struct {
+		ID int64 `json:"tranId"`
+	}
+
+	err := m.client.signedCall(&result, "POST", "/sapi/v1/margin/borrow-repay", params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.ID, nil
+}
+
+// MaxBorrowable returns how much of asset can currently be borrowed.
+func (m *Margin) MaxBorrowable(asset string) (*MaxBorrowable, error) {
+	params := []func(url.Values){
+		param("asset", asset),
+	}
+
+	if m.Isolated() {
+		params = append(params, param("isolatedSymbol", m.symbol))
+	}
+
+	var result MaxBorrowable
+
+	err := m.client.signedCall(&result, "GET", "/sapi/v1/margin/maxBorrowable", params...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// InterestHistory lists interest charged on asset. Leave asset empty for
+// all assets. Without options, interest from the last 7 days is returned.
+// You can refine the query with StartTime() and EndTime(), and page through
+// the results like Client.Transfers().
+func (m *Margin) InterestHistory(asset string, page int, size int, options ...QueryFunc) ([]MarginInterest, int, error) {
+	params := []func(url.Values){
+		param("current", page),
+		param("size", size),
+		newQuery(options).params(),
+	}
+
+	if asset != "" {
+		params = append(params, param("asset", asset))
+	}
+
+	if m.Isolated() {
+		params = append(params, param("isolatedSymbol", m.symbol))
+	}
+
+	var result struct {
+		Total int              `json:"total"`
+		Rows  []MarginInterest `json:"rows"`
+	}
+
+	err := m.client.signedCall(&result, "GET", "/sapi/v1/margin/interestHistory", params...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return result.Rows, result.Total, nil
+}
+
+// listenKeys returns the endpoint managing listen keys for the account.
+func (m *Margin) listenKeys() listenKeys {
+	if m.Isolated() {
+		return listenKeys{
+			uri:    "/sapi/v1/userDataStream/isolated",
+			params: []func(url.Values){param("symbol", m.symbol)},
+		}
+	}
+
+	return listenKeys{uri: "/sapi/v1/userDataStream"}
+}
+
+// UserDataStream will open a stream of events concerning the margin
+// account. It's used like Client.UserDataStream().
+func (m *Margin) UserDataStream() (*UserDataStream, error) {
+	return m.client.userDataStream(m.listenKeys(), userDataEvent)
+}
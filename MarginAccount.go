@@ -0,0 +1,125 @@
+package binance
+
+import (
+	"net/url"
+	"strings"
+)
+
+// MarginAsset is the balance and debt of an asset in a margin account.
+type MarginAsset struct {
+	Asset    string `json:"asset"` // FIXME: type
+	Free     Value  `json:"free"`
+	Locked   Value  `json:"locked"`
+	Borrowed Value  `json:"borrowed"`
+	Interest Value  `json:"interest"`
+	NetAsset Value  `json:"netAsset"`
+}
+
+// MarginAccount describes the cross margin account.
+type MarginAccount struct {
+	Created             bool          `json:"created"`
+	BorrowEnabled       bool          `json:"borrowEnabled"`
+	TradeEnabled        bool          `json:"tradeEnabled"`
+	TransferInEnabled   bool          `json:"transferInEnabled"`
+	TransferOutEnabled  bool          `json:"transferOutEnabled"`
+	MarginLevel         Value         `json:"marginLevel"`
+	TotalAssetOfBTC     Value         `json:"totalAssetOfBtc"`
+	TotalLiabilityOfBTC Value         `json:"totalLiabilityOfBtc"`
+	TotalNetAssetOfBTC  Value         `json:"totalNetAssetOfBtc"`
+	AccountType         string        `json:"accountType"` // FIXME: type
+	Assets              []MarginAsset `json:"userAssets"`
+}
+
+// Asset returns the balance of asset, and false if the account doesn't
+// hold it.
+func (a *MarginAccount) Asset(asset string) (MarginAsset, bool) {
+	for _, b := range a.Assets {
+		if b.Asset == asset {
+			return b, true
+		}
+	}
+
+	return MarginAsset{}, false
+}
+
+// MarginAccount retrieves the cross margin account.
+func (c *Client) MarginAccount() (*MarginAccount, error) {
+	var account MarginAccount
+
+	err := c.signedCall(&account, "GET", "/sapi/v1/margin/account")
+	if err != nil {
+		return nil, err
+	}
+
+	return &account, nil
+}
+
+// IsolatedMarginAsset is the balance and debt of an asset in an isolated
+// margin account.
+type IsolatedMarginAsset struct {
+	MarginAsset
+
+	BorrowEnabled bool  `json:"borrowEnabled"`
+	RepayEnabled  bool  `json:"repayEnabled"`
+	TotalAsset    Value `json:"totalAsset"`
+}
+
+// IsolatedMarginSymbol is the isolated margin account of a single symbol.
+type IsolatedMarginSymbol struct {
+	Symbol            Symbol              `json:"symbol"`
+	BaseAsset         IsolatedMarginAsset `json:"baseAsset"`
+	QuoteAsset        IsolatedMarginAsset `json:"quoteAsset"`
+	Enabled           bool                `json:"enabled"`
+	Created           bool                `json:"isolatedCreated"`
+	TradeEnabled      bool                `json:"tradeEnabled"`
+	MarginLevel       Value               `json:"marginLevel"`
+	MarginLevelStatus string              `json:"marginLevelStatus"` // FIXME: type
+	MarginRatio       Value               `json:"marginRatio"`
+	IndexPrice        Value               `json:"indexPrice"`
+	LiquidatePrice    Value               `json:"liquidatePrice"`
+	LiquidateRate     Value               `json:"liquidateRate"`
+}
+
+// IsolatedMarginAccount describes the isolated margin accounts.
+type IsolatedMarginAccount struct {
+	Symbols             []IsolatedMarginSymbol `json:"assets"`
+	TotalAssetOfBTC     Value                  `json:"totalAssetOfBtc"`
+	TotalLiabilityOfBTC Value                  `json:"totalLiabilityOfBtc"`
+	TotalNetAssetOfBTC  Value                  `json:"totalNetAssetOfBtc"`
+}
+
+// Symbol returns the isolated margin account of symbol, and false if there
+// is none.
+func (a *IsolatedMarginAccount) Symbol(symbol Symbol) (IsolatedMarginSymbol, bool) {
+	for _, s := range a.Symbols {
+		if s.Symbol.UpperCase() == symbol.UpperCase() {
+			return s, true
+		}
+	}
+
+	return IsolatedMarginSymbol{}, false
+}
+
+// IsolatedMarginAccount retrieves the isolated margin accounts. If symbols
+// are given, at most 5, only their accounts are returned.
+func (c *Client) IsolatedMarginAccount(symbols ...Symbol) (*IsolatedMarginAccount, error) {
+	params := []func(url.Values){}
+
+	if len(symbols) > 0 {
+		list := make([]string, len(symbols))
+		for i, s := range symbols {
+			list[i] = s.UpperCase()
+		}
+
+		params = append(params, param("symbols", strings.Join(list, ",")))
+	}
+
+	var account IsolatedMarginAccount
+
+	err := c.signedCall(&account, "GET", "/sapi/v1/margin/isolated/account", params...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &account, nil
+}
@@ -0,0 +1,20 @@
+package binance
+
+// MarginInterest is interest charged on a margin loan.
+type MarginInterest struct {
+	ID             int64  `json:"txId"`
+	Time           Time   `json:"interestAccuredTime"`
+	Asset          string `json:"asset"`    // FIXME: type
+	RawAsset       string `json:"rawAsset"` // FIXME: type
+	Principal      Value  `json:"principal"`
+	Interest       Value  `json:"interest"`
+	InterestRate   Value  `json:"interestRate"`
+	Type           string `json:"type"` // FIXME: type
+	IsolatedSymbol Symbol `json:"isolatedSymbol"`
+}
+
+// MaxBorrowable is how much of an asset can be borrowed.
+type MaxBorrowable struct {
+	Amount      Value `json:"amount"`
+	BorrowLimit Value `json:"borrowLimit"`
+}
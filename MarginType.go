@@ -0,0 +1,42 @@
+package binance
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// MarginType is the margin mode of a futures position.
+type MarginType string
+
+// The different margin modes.
+const (
+	MarginTypeIsolated MarginType = "ISOLATED"
+	MarginTypeCrossed  MarginType = "CROSSED"
+)
+
+// UnmarshalJSON implements json.Unmarshaler while making sure only enums
+// that we know about end up in a MarginType variable. Positions report the
+// margin type in lower case, and crossed as "cross".
+func (m *MarginType) UnmarshalJSON(data []byte) error {
+	str := ""
+	err := json.Unmarshal(data, &str)
+	if err != nil {
+		return err
+	}
+
+	switch str {
+	case "ISOLATED", "isolated":
+		*m = MarginTypeIsolated
+	case "CROSSED", "cross":
+		*m = MarginTypeCrossed
+	default:
+		return fmt.Errorf("%s is not a valid margin type", str)
+	}
+
+	return nil
+}
+
+// String implement Stringer.
+func (m MarginType) String() string {
+	return string(m)
+}
@@ -0,0 +1,175 @@
+package binance
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestMargin(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+
+		switch r.Method + " " + r.URL.Path {
+		case "GET /sapi/v1/margin/account":
+			fmt.Fprint(w, `{"borrowEnabled":true,"marginLevel":"11.6","userAssets":[{"asset":"BTC","borrowed":"0.1","free":"1","interest":"0.0001","locked":"0","netAsset":"0.8999"}]}`)
+
+		case "GET /sapi/v1/margin/isolated/account":
+			if q.Get("symbols") != "BTCUSDT" {
+				t.Errorf("got account query %s", r.URL.RawQuery)
+			}
+
+			fmt.Fprint(w, `{"assets":[{"symbol":"BTCUSDT","baseAsset":{"asset":"BTC","borrowEnabled":true,"borrowed":"0.5","totalAsset":"1.5"},"quoteAsset":{"asset":"USDT"},"marginLevelStatus":"NORMAL"}]}`)
+
+		case "POST /sapi/v1/margin/order":
+			if q.Get("isIsolated") != "TRUE" || q.Get("sideEffectType") != "MARGIN_BUY" || q.Get("newClientOrderId") == "" {
+				t.Errorf("got order %s", r.URL.RawQuery)
+			}
+
+			if q.Get("quantity") == "2" {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+
+			fmt.Fprintf(w, `{"symbol":"BTCUSDT","orderId":1,"clientOrderId":"%s","status":"NEW"}`, q.Get("newClientOrderId"))
+
+		case "GET /sapi/v1/margin/order":
+			if q.Get("isIsolated") != "TRUE" {
+				t.Errorf("got order query %s", r.URL.RawQuery)
+			}
+
+			fmt.Fprintf(w, `{"symbol":"BTCUSDT","orderId":2,"clientOrderId":"%s","status":"FILLED"}`, q.Get("origClientOrderId"))
+
+		case "GET /sapi/v1/margin/openOrders":
+			if q.Get("isIsolated") != "" || q.Get("symbol") != "" {
+				t.Errorf("got open orders query %s", r.URL.RawQuery)
+			}
+
+			fmt.Fprint(w, `[{"symbol":"ETHBTC","orderId":3,"status":"NEW"}]`)
+
+		case "DELETE /sapi/v1/margin/openOrders":
+			fmt.Fprint(w, `[{"symbol":"BTCUSDT","orderId":4,"status":"CANCELED"},{"symbol":"BTCUSDT","orderListId":5,"contingencyType":"OCO","listStatusType":"ALL_DONE","listOrderStatus":"ALL_DONE"}]`)
+
+		case "POST /sapi/v1/margin/borrow-repay":
+			expected := "amount=0.5&asset=BTC&isIsolated=FALSE&type=BORROW"
+			if q.Get("type") == "REPAY" {
+				expected = "amount=0.5&asset=BTC&isIsolated=TRUE&symbol=BTCUSDT&type=REPAY"
+			}
+
+			q.Del("timestamp")
+			q.Del("signature")
+
+			if q.Encode() != expected {
+				t.Errorf("got %s, expected %s", q.Encode(), expected)
+			}
+
+			fmt.Fprint(w, `{"tranId":100000001}`)
+
+		case "GET /sapi/v1/margin/maxBorrowable":
+			if q.Get("isolatedSymbol") != "BTCUSDT" {
+				t.Errorf("got max borrowable query %s", r.URL.RawQuery)
+			}
+
+			fmt.Fprint(w, `{"amount":"1.69248805","borrowLimit":"60"}`)
+
+		case "GET /sapi/v1/margin/interestHistory":
+			fmt.Fprint(w, `{"rows":[{"txId":1352286576452864727,"interestAccuredTime":1672160400000,"asset":"USDT","principal":"45.3313","interest":"0.00024995","interestRate":"0.00013233","type":"ON_BORROW"}],"total":1}`)
+
+		case "POST /sapi/v1/userDataStream/isolated":
+			if q.Get("symbol") != "BTCUSDT" {
+				t.Errorf("got listen key request %s", r.URL.RawQuery)
+			}
+
+			fmt.Fprint(w, `{"listenKey":"isolated"}`)
+
+		default:
+			t.Errorf("unexpected request for %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(APIKey("key"), APISecret("secret"), BaseURL(server.URL))
+
+	account, err := client.MarginAccount()
+	if err != nil {
+		t.Fatalf("MarginAccount returned %v", err)
+	}
+
+	btc, found := account.Asset("BTC")
+	if !found || btc.Borrowed != "0.1" || account.MarginLevel != "11.6" {
+		t.Errorf("MarginAccount returned %+v", account)
+	}
+
+	isolatedAccount, err := client.IsolatedMarginAccount("btcusdt")
+	if err != nil {
+		t.Fatalf("IsolatedMarginAccount returned %v", err)
+	}
+
+	s, found := isolatedAccount.Symbol("btcusdt")
+	if !found || s.BaseAsset.Borrowed != "0.5" || s.BaseAsset.TotalAsset != "1.5" {
+		t.Errorf("IsolatedMarginAccount returned %+v", isolatedAccount)
+	}
+
+	cross := client.CrossMargin()
+	isolated := client.IsolatedMargin("btcusdt", SideEffect(SideEffectMarginBuy))
+
+	if cross.Isolated() || !isolated.Isolated() || isolated.Symbol() != "BTCUSDT" {
+		t.Errorf("wrong modes")
+	}
+
+	order := &Order{Symbol: "BTCUSDT", Side: OrderSideBuy, Type: OrderTypeMarket, Quantity: "1"}
+
+	err = isolated.SubmitOrder(order)
+	if err != nil || order.ID != 1 {
+		t.Errorf("SubmitOrder returned %+v %v", order, err)
+	}
+
+	// The order is found after Binance failed to tell whether it was
+	// accepted.
+	defer func(delay time.Duration) { unknownExecutionDelay = delay }(unknownExecutionDelay)
+	unknownExecutionDelay = 0
+
+	order = &Order{Symbol: "BTCUSDT", Side: OrderSideBuy, Type: OrderTypeMarket, Quantity: "2"}
+
+	err = isolated.SubmitOrder(order)
+	if err != nil || order.ID != 2 || order.Status != Filled {
+		t.Errorf("SubmitOrder returned %+v %v", order, err)
+	}
+
+	open, err := cross.OpenOrders("")
+	if err != nil || len(open) != 1 {
+		t.Errorf("OpenOrders returned %+v %v", open, err)
+	}
+
+	orders, lists, err := isolated.CancelAllOrders("BTCUSDT")
+	if err != nil || len(orders) != 1 || len(lists) != 1 {
+		t.Errorf("CancelAllOrders returned %+v %+v %v", orders, lists, err)
+	}
+
+	id, err := cross.Borrow("BTC", "0.5")
+	if err != nil || id != 100000001 {
+		t.Errorf("Borrow returned %d %v", id, err)
+	}
+
+	_, err = isolated.Repay("BTC", "0.5")
+	if err != nil {
+		t.Errorf("Repay returned %v", err)
+	}
+
+	max, err := isolated.MaxBorrowable("BTC")
+	if err != nil || max.Amount != "1.69248805" {
+		t.Errorf("MaxBorrowable returned %+v %v", max, err)
+	}
+
+	interest, total, err := cross.InterestHistory("USDT", 1, 10)
+	if err != nil || total != 1 || len(interest) != 1 || interest[0].Time.Unix() != 1672160400 {
+		t.Errorf("InterestHistory returned %+v %d %v", interest, total, err)
+	}
+
+	key, err := isolated.listenKeys().create(client)
+	if err != nil || key != "isolated" {
+		t.Errorf("listen key returned %s %v", key, err)
+	}
+}
@@ -1,10 +1,16 @@
 package binance
 
 import (
+	"encoding/json"
 	"errors"
 	"net/url"
+	"time"
 )
 
+// unknownExecutionDelay is how long we wait before asking for the status of
+// an order, after Binance failed to tell us if it was accepted.
+var unknownExecutionDelay = time.Second
+
 // Order describes an order in the Binance Exchange.
 type Order struct {
 	Symbol                   Symbol      `json:"symbol"`
@@ -24,11 +30,93 @@ type Order struct {
 	Time                     Time        `json:"time"`
 	Updated                  Time        `json:"updateTime"`
 	Working                  bool        `json:"isWorking"`
+
+	// QuoteOrderQuantity can be set instead of Quantity for market orders.
+	// The order will then buy or sell for this amount of the quote asset.
+	QuoteOrderQuantity Value `json:"origQuoteOrderQty"`
+
+	// TrailingDelta is the trailing stop in basis points. It can be used
+	// instead of, or along with, StopPrice for stop loss and take profit
+	// orders.
+	TrailingDelta int `json:"trailingDelta"`
+
+	StrategyID              int64                   `json:"strategyId"`
+	StrategyType            int                     `json:"strategyType"`
+	SelfTradePreventionMode SelfTradePreventionMode `json:"selfTradePreventionMode"`
+
+	// TransactionTime and Fills are only set in response to SubmitOrder().
+	// Fills are only returned when ResponseType is OrderResponseFull.
+	TransactionTime Time   `json:"transactTime"`
+	Fills           []Fill `json:"fills"`
+
+	// ResponseType decides how much SubmitOrder() will learn about the
+	// order. If not set, OrderResponseResult is used.
+	ResponseType OrderResponseType `json:"-"`
 }
 
-// SubmitOrder will submit order for processing.
+// SubmitOrder will submit order for processing. order will be updated with
+// the response from Binance. Depending on order.ResponseType this will
+// include the order ID, status, executed quantity and fills.
+//
+// If order.ClientOrderID is empty, a new ID is generated. If the request
+// times out, or Binance answers with a 5xx status, the order is looked up by
+// the client order ID. If it exists, order is updated with the status and no
+// error is returned, but the fills will be unknown. If it doesn't exist, the
+// order is submitted again if allowed by SubmitRetries().
 func (c *Client) SubmitOrder(order *Order) error {
-	return c.submitOrder("/api/v3/order", order)
+	return c.submitRecovering(order, "/api/v3/order", c.OrderStatus)
+}
+
+// submitRecovering submits order to uri, and recovers from unknown
+// executions as described for SubmitOrder(). status is used for looking up
+// the order.
+func (c *Client) submitRecovering(order *Order, uri string, status func(Symbol, string, int) (*Order, error), extra ...func(url.Values)) error {
+	if order.ClientOrderID == "" {
+		order.ClientOrderID = c.NewClientOrderID()
+	}
+
+	submit := func() error {
+		return c.submitOrder(uri, order, extra...)
+	}
+
+	lookup := func() error {
+		existing, err := status(order.Symbol, order.ClientOrderID, 0)
+		if err != nil {
+			return err
+		}
+
+		responseType := order.ResponseType
+		*order = *existing
+		order.ResponseType = responseType
+
+		return nil
+	}
+
+	return c.retrySubmit(submit, lookup)
+}
+
+// retrySubmit calls submit. If we can't tell whether the submission was
+// executed, lookup is called for finding the result. If lookup fails
+// because the order doesn't exist, submit is retried as allowed by
+// SubmitRetries().
+func (c *Client) retrySubmit(submit func() error, lookup func() error) error {
+	for attempt := 0; ; attempt++ {
+		err := submit()
+		if err == nil || !unknownExecution(err) {
+			return err
+		}
+
+		time.Sleep(unknownExecutionDelay)
+
+		statusErr := lookup()
+		if statusErr == nil {
+			return nil
+		}
+
+		if !noSuchOrder(statusErr) || attempt >= c.submitRetries {
+			return err
+		}
+	}
 }
 
 // SubmitTestOrder will submit a test order.
@@ -36,15 +124,35 @@ func (c *Client) SubmitTestOrder(order *Order) error {
 	return c.submitOrder("/api/v3/order/test", order)
 }
 
-func (c *Client) submitOrder(uri string, order *Order) error {
-	var result interface{}
+func (c *Client) submitOrder(uri string, order *Order, extra ...func(url.Values)) error {
+	err := order.Validate()
+	if err != nil {
+		return err
+	}
+
+	return c.signedCall(order, "POST", uri, append(orderParams(order), extra...)...)
+}
+
+// orderParams returns the parameters used for submitting order.
+func orderParams(order *Order) []func(url.Values) {
+	responseType := order.ResponseType
+	if responseType == zeroOrderResponseType {
+		responseType = OrderResponseResult
+	}
 
 	params := []func(url.Values){
-		param("newOrderRespType", "RESULT"),
+		param("newOrderRespType", string(responseType)),
 		param("symbol", order.Symbol),
 		param("side", order.Side),
 		param("type", order.Type),
-		param("quantity", order.Quantity),
+	}
+
+	if order.Quantity != zeroValue {
+		params = append(params, param("quantity", order.Quantity))
+	}
+
+	if order.QuoteOrderQuantity != zeroValue {
+		params = append(params, param("quoteOrderQty", order.QuoteOrderQuantity))
 	}
 
 	if order.TimeInForce != zeroTimeInForceZero {
@@ -59,24 +167,36 @@ func (c *Client) submitOrder(uri string, order *Order) error {
 		params = append(params, param("stopPrice", order.StopPrice))
 	}
 
-	if order.ID != 0 {
-		params = append(params, param("newClientOrderId", order.ID))
+	if order.ClientOrderID != "" {
+		params = append(params, param("newClientOrderId", order.ClientOrderID))
 	}
 
 	if order.IcebergQuantity != zeroValue {
 		params = append(params, param("icebergQty", order.IcebergQuantity))
 	}
 
-	err := c.signedCall(&result, "POST", uri, params...)
-	if err != nil {
-		return err
+	if order.TrailingDelta != 0 {
+		params = append(params, param("trailingDelta", order.TrailingDelta))
+	}
+
+	if order.StrategyID != 0 {
+		params = append(params, param("strategyId", order.StrategyID))
 	}
 
-	return nil
+	if order.StrategyType != 0 {
+		params = append(params, param("strategyType", order.StrategyType))
+	}
+
+	if order.SelfTradePreventionMode != zeroSelfTradePreventionMode {
+		params = append(params, param("selfTradePreventionMode", order.SelfTradePreventionMode))
+	}
+
+	return params
 }
 
-// CancelOrder cancels a live order.
-func (c *Client) CancelOrder(symbol Symbol, clientOrderID string, id int) (*Order, error) {
+// orderIDParams returns the parameters used for identifying an existing
+// order. Exactly one of clientOrderID and id must be set.
+func orderIDParams(symbol Symbol, clientOrderID string, id int) ([]func(url.Values), error) {
 	params := []func(url.Values){
 		param("symbol", symbol),
 	}
@@ -97,8 +217,18 @@ func (c *Client) CancelOrder(symbol Symbol, clientOrderID string, id int) (*Orde
 		params = append(params, param("orderId", id))
 	}
 
+	return params, nil
+}
+
+// CancelOrder cancels a live order.
+func (c *Client) CancelOrder(symbol Symbol, clientOrderID string, id int) (*Order, error) {
+	params, err := orderIDParams(symbol, clientOrderID, id)
+	if err != nil {
+		return nil, err
+	}
+
 	var order Order
-	err := c.signedCall(&order, "DELETE", "/api/v3/order", params...)
+	err = c.signedCall(&order, "DELETE", "/api/v3/order", params...)
 	if err != nil {
 		return nil, err
 	}
@@ -106,30 +236,115 @@ func (c *Client) CancelOrder(symbol Symbol, clientOrderID string, id int) (*Orde
 	return &order, nil
 }
 
-// OrderStatus queries the status of an order.
-func (c *Client) OrderStatus(symbol Symbol, clientOrderID string, id int) (*Order, error) {
-	params := []func(url.Values){
-		param("symbol", symbol),
+// CancelAllOrders cancels all open orders on symbol, including order lists.
+// Orders that are part of a list are only returned as part of the list.
+func (c *Client) CancelAllOrders(symbol Symbol) ([]Order, []OrderList, error) {
+	var results []json.RawMessage
+
+	err := c.signedCall(&results, "DELETE", "/api/v3/openOrders", param("symbol", symbol))
+	if err != nil {
+		return nil, nil, err
 	}
 
-	if clientOrderID == "" && id == 0 {
-		return nil, errors.New("clientOrderID and ID empty")
+	return splitCancelled(results)
+}
+
+// splitCancelled decodes the result of cancelling all open orders, which
+// mixes orders and order lists.
+func splitCancelled(results []json.RawMessage) ([]Order, []OrderList, error) {
+	var orders []Order
+	var lists []OrderList
+
+	for _, result := range results {
+		var probe struct {
+			ContingencyType *string `json:"contingencyType"`
+		}
+
+		err := json.Unmarshal(result, &probe)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if probe.ContingencyType != nil {
+			var list OrderList
+			err = json.Unmarshal(result, &list)
+			lists = append(lists, list)
+		} else {
+			var order Order
+			err = json.Unmarshal(result, &order)
+			orders = append(orders, order)
+		}
+
+		if err != nil {
+			return nil, nil, err
+		}
 	}
 
-	if clientOrderID != "" && id != 0 {
-		return nil, errors.New("clientOrderID and ID both set")
+	return orders, lists, nil
+}
+
+// CancelReplaceOrder will cancel an existing order identified by
+// cancelClientOrderID or cancelID, and place order. mode decides whether
+// order is placed if the cancel fails. If either half fails, the result is
+// returned along with the *APIError, so the caller can see exactly what
+// happened. order will be updated with the response from Binance if placed.
+func (c *Client) CancelReplaceOrder(mode CancelReplaceMode, cancelClientOrderID string, cancelID int, order *Order) (*CancelReplaceResult, error) {
+	if cancelClientOrderID == "" && cancelID == 0 {
+		return nil, errors.New("cancelClientOrderID and cancelID empty")
 	}
 
-	if clientOrderID != "" {
-		params = append(params, param("origClientOrderId", clientOrderID))
+	if cancelClientOrderID != "" && cancelID != 0 {
+		return nil, errors.New("cancelClientOrderID and cancelID both set")
 	}
 
-	if id != 0 {
-		params = append(params, param("orderId", id))
+	if order.ClientOrderID == "" {
+		order.ClientOrderID = c.NewClientOrderID()
+	}
+
+	err := order.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	params := append(orderParams(order), param("cancelReplaceMode", string(mode)))
+
+	if cancelClientOrderID != "" {
+		params = append(params, param("cancelOrigClientOrderId", cancelClientOrderID))
+	}
+
+	if cancelID != 0 {
+		params = append(params, param("cancelOrderId", cancelID))
+	}
+
+	result := &CancelReplaceResult{}
+
+	err = c.signedCall(result, "POST", "/api/v3/order/cancelReplace", params...)
+	if apiErr, ok := err.(*APIError); ok && len(apiErr.Data) > 0 {
+		if json.Unmarshal(apiErr.Data, result) != nil {
+			return nil, err
+		}
+	} else if err != nil {
+		return nil, err
+	}
+
+	if result.NewOrder != nil {
+		responseType := order.ResponseType
+		*order = *result.NewOrder
+		order.ResponseType = responseType
+	}
+
+	return result, err
+}
+
+// OrderStatus queries the status of an order.
+func (c *Client) OrderStatus(symbol Symbol, clientOrderID string, id int) (*Order, error) {
+	params, err := orderIDParams(symbol, clientOrderID, id)
+	if err != nil {
+		return nil, err
 	}
 
 	var order Order
-	err := c.signedCall(&order, "GET", "/api/v3/order", params...)
+	err = c.signedCall(&order, "GET", "/api/v3/order", params...)
 	if err != nil {
 		return nil, err
 	}
@@ -154,13 +369,68 @@ func (c *Client) OpenOrders(symbol Symbol) ([]Order, error) {
 	return results, nil
 }
 
-// AllOrders will list all orders open or closed.
-func (c *Client) AllOrders(symbol Symbol) ([]Order, error) {
+// AllOrders will list all orders open or closed. Without options, the 500
+// most recent orders are returned. You can refine the query with OrderID(),
+// StartTime(), EndTime() and Limit(). StartTime() and EndTime() can't be more
+// than 24 hours apart, use OrderHistory() for walking longer periods.
+func (c *Client) AllOrders(symbol Symbol, options ...QueryFunc) ([]Order, error) {
 	results := make([]Order, 0, 100)
-	err := c.signedCall(&results, "GET", "/api/v3/allOrders", param("symbol", symbol))
+	err := c.signedCall(&results, "GET", "/api/v3/allOrders",
+		param("symbol", symbol),
+		newQuery(options).params(),
+	)
 	if err != nil {
 		return nil, err
 	}
 
 	return results, nil
 }
+
+// OrderIterator walks all orders for a symbol in a period. It's used like
+// OrderListIterator.
+type OrderIterator struct {
+	walker *historyWalker
+	order  Order
+}
+
+// orderPage is a page of orders.
+type orderPage []Order
+
+func (p orderPage) Len() int        { return len(p) }
+func (p orderPage) ID(i int) int64  { return int64(p[i].ID) }
+func (p orderPage) Time(i int) Time { return p[i].Time }
+
+// OrderHistory returns an iterator walking all orders for symbol created from
+// start until end.
+func (c *Client) OrderHistory(symbol Symbol, start Time, end Time) *OrderIterator {
+	fetch := func(options ...QueryFunc) (historyPage, error) {
+		orders, err := c.AllOrders(symbol, options...)
+
+		return orderPage(orders), err
+	}
+
+	return &OrderIterator{
+		walker: newHistoryWalker(start, end, OrderID, fetch),
+	}
+}
+
+// Next advances the iterator to the next order. It returns false when there
+// are no more orders or an error occurred.
+func (it *OrderIterator) Next() bool {
+	page, i, ok := it.walker.advance()
+	if ok {
+		it.order = page.(orderPage)[i]
+	}
+
+	return ok
+}
+
+// Order returns the current order.
+func (it *OrderIterator) Order() Order {
+	return it.order
+}
+
+// Err returns the error that stopped the iterator, if any.
+func (it *OrderIterator) Err() error {
+	return it.walker.err
+}
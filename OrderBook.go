@@ -29,7 +29,8 @@ type OrderBookPoint struct {
 func (p *orderBookProxy) real() (*OrderBook, error) {
 	convert := func(in [][]interface{}, out []OrderBookPoint) error {
 		for i, b := range in {
-			if len(b) == 3 {
+			// Older API versions would send a third (ignored) element.
+			if len(b) >= 2 {
 				p, ok := b[0].(string)
 				if !ok {
 					return errors.New("unknown format")
@@ -0,0 +1,338 @@
+package binance
+
+import (
+	"fmt"
+)
+
+// OrderOption sets optional fields on orders created by the order
+// constructors like LimitBuy() and StopLossLimit().
+type OrderOption func(*Order)
+
+// WithTimeInForce overrides the default time in force of GTC.
+func WithTimeInForce(timeInForce TimeInForce) OrderOption {
+	return func(o *Order) {
+		o.TimeInForce = timeInForce
+	}
+}
+
+// WithClientOrderID sets the client order ID. If not set, an ID will be
+// generated when the order is submitted.
+func WithClientOrderID(clientOrderID string) OrderOption {
+	return func(o *Order) {
+		o.ClientOrderID = clientOrderID
+	}
+}
+
+// WithIcebergQuantity will only show quantity of the order in the order
+// book.
+func WithIcebergQuantity(quantity Value) OrderOption {
+	return func(o *Order) {
+		o.IcebergQuantity = quantity
+	}
+}
+
+// WithTrailingDelta makes a stop loss or take profit order trailing by delta
+// basis points.
+func WithTrailingDelta(delta int) OrderOption {
+	return func(o *Order) {
+		o.TrailingDelta = delta
+	}
+}
+
+// WithStrategy tags the order with a strategy ID and type. strategyType
+// must be 1000000 or above.
+func WithStrategy(id int64, strategyType int) OrderOption {
+	return func(o *Order) {
+		o.StrategyID = id
+		o.StrategyType = strategyType
+	}
+}
+
+// WithSelfTradePrevention sets the self-trade prevention mode.
+func WithSelfTradePrevention(mode SelfTradePreventionMode) OrderOption {
+	return func(o *Order) {
+		o.SelfTradePreventionMode = mode
+	}
+}
+
+// WithResponseType sets the response type used when submitting the order.
+func WithResponseType(responseType OrderResponseType) OrderOption {
+	return func(o *Order) {
+		o.ResponseType = responseType
+	}
+}
+
+// newOrder will apply options to order and validate the result.
+func newOrder(order *Order, options []OrderOption) (*Order, error) {
+	for _, option := range options {
+		option(order)
+	}
+
+	err := order.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// LimitOrder returns a limit order.
+func LimitOrder(symbol Symbol, side OrderSide, quantity Value, price Value, options ...OrderOption) (*Order, error) {
+	return newOrder(&Order{
+		Symbol:      symbol,
+		Side:        side,
+		Type:        OrderTypeLimit,
+		TimeInForce: GTC,
+		Quantity:    quantity,
+		Price:       price,
+	}, options)
+}
+
+// LimitBuy returns a limit order buying quantity at price.
+func LimitBuy(symbol Symbol, quantity Value, price Value, options ...OrderOption) (*Order, error) {
+	return LimitOrder(symbol, OrderSideBuy, quantity, price, options...)
+}
+
+// LimitSell returns a limit order selling quantity at price.
+func LimitSell(symbol Symbol, quantity Value, price Value, options ...OrderOption) (*Order, error) {
+	return LimitOrder(symbol, OrderSideSell, quantity, price, options...)
+}
+
+// MarketOrder returns a market order for quantity of the base asset.
+func MarketOrder(symbol Symbol, side OrderSide, quantity Value, options ...OrderOption) (*Order, error) {
+	return newOrder(&Order{
+		Symbol:   symbol,
+		Side:     side,
+		Type:     OrderTypeMarket,
+		Quantity: quantity,
+	}, options)
+}
+
+// MarketBuy returns a market order buying quantity.
+func MarketBuy(symbol Symbol, quantity Value, options ...OrderOption) (*Order, error) {
+	return MarketOrder(symbol, OrderSideBuy, quantity, options...)
+}
+
+// MarketSell returns a market order selling quantity.
+func MarketSell(symbol Symbol, quantity Value, options ...OrderOption) (*Order, error) {
+	return MarketOrder(symbol, OrderSideSell, quantity, options...)
+}
+
+// MarketQuoteOrder returns a market order for quoteQuantity of the quote
+// asset.
+func MarketQuoteOrder(symbol Symbol, side OrderSide, quoteQuantity Value, options ...OrderOption) (*Order, error) {
+	return newOrder(&Order{
+		Symbol:             symbol,
+		Side:               side,
+		Type:               OrderTypeMarket,
+		QuoteOrderQuantity: quoteQuantity,
+	}, options)
+}
+
+// MarketBuyQuote returns a market order spending quoteQuantity of the quote
+// asset.
+func MarketBuyQuote(symbol Symbol, quoteQuantity Value, options ...OrderOption) (*Order, error) {
+	return MarketQuoteOrder(symbol, OrderSideBuy, quoteQuantity, options...)
+}
+
+// MarketSellQuote returns a market order selling for quoteQuantity of the
+// quote asset.
+func MarketSellQuote(symbol Symbol, quoteQuantity Value, options ...OrderOption) (*Order, error) {
+	return MarketQuoteOrder(symbol, OrderSideSell, quoteQuantity, options...)
+}
+
+// StopLoss returns a market order triggered when the price reaches
+// stopPrice. stopPrice can be left empty for trailing stops.
+func StopLoss(symbol Symbol, side OrderSide, quantity Value, stopPrice Value, options ...OrderOption) (*Order, error) {
+	return newOrder(&Order{
+		Symbol:    symbol,
+		Side:      side,
+		Type:      OrderTypeStopLoss,
+		Quantity:  quantity,
+		StopPrice: stopPrice,
+	}, options)
+}
+
+// StopLossLimit returns a limit order placed at price when the price reaches
+// stopPrice.
+func StopLossLimit(symbol Symbol, side OrderSide, quantity Value, price Value, stopPrice Value, options ...OrderOption) (*Order, error) {
+	return newOrder(&Order{
+		Symbol:      symbol,
+		Side:        side,
+		Type:        OrderTypeStopLossLimit,
+		TimeInForce: GTC,
+		Quantity:    quantity,
+		Price:       price,
+		StopPrice:   stopPrice,
+	}, options)
+}
+
+// TakeProfit returns a market order triggered when the price reaches
+// stopPrice. stopPrice can be left empty for trailing orders.
+func TakeProfit(symbol Symbol, side OrderSide, quantity Value, stopPrice Value, options ...OrderOption) (*Order, error) {
+	return newOrder(&Order{
+		Symbol:    symbol,
+		Side:      side,
+		Type:      OrderTypeTakeProfit,
+		Quantity:  quantity,
+		StopPrice: stopPrice,
+	}, options)
+}
+
+// TakeProfitLimit returns a limit order placed at price when the price
+// reaches stopPrice.
+func TakeProfitLimit(symbol Symbol, side OrderSide, quantity Value, price Value, stopPrice Value, options ...OrderOption) (*Order, error) {
+	return newOrder(&Order{
+		Symbol:      symbol,
+		Side:        side,
+		Type:        OrderTypeTakeProfitLimit,
+		TimeInForce: GTC,
+		Quantity:    quantity,
+		Price:       price,
+		StopPrice:   stopPrice,
+	}, options)
+}
+
+// LimitMaker returns a limit order that will be rejected if it would match
+// immediately.
+func LimitMaker(symbol Symbol, side OrderSide, quantity Value, price Value, options ...OrderOption) (*Order, error) {
+	return newOrder(&Order{
+		Symbol:   symbol,
+		Side:     side,
+		Type:     OrderTypeLimitMaker,
+		Quantity: quantity,
+		Price:    price,
+	}, options)
+}
+
+// Iceberg returns a limit order only showing icebergQuantity in the order
+// book.
+func Iceberg(symbol Symbol, side OrderSide, quantity Value, price Value, icebergQuantity Value, options ...OrderOption) (*Order, error) {
+	return newOrder(&Order{
+		Symbol:          symbol,
+		Side:            side,
+		Type:            OrderTypeLimit,
+		TimeInForce:     GTC,
+		Quantity:        quantity,
+		Price:           price,
+		IcebergQuantity: icebergQuantity,
+	}, options)
+}
+
+// orderFields describes which fields an order type requires and allows.
+type orderFields struct {
+	price       bool
+	stopPrice   bool
+	timeInForce bool
+	iceberg     bool
+	quote       bool
+}
+
+// orderTypeFields lists the fields allowed for each order type. Allowed
+// prices and time in force are also required.
+var orderTypeFields = map[OrderType]orderFields{
+	OrderTypeLimit:           {price: true, timeInForce: true, iceberg: true},
+	OrderTypeLimitMaker:      {price: true, iceberg: true},
+	OrderTypeMarket:          {quote: true},
+	OrderTypeStopLoss:        {stopPrice: true},
+	OrderTypeStopLossLimit:   {price: true, stopPrice: true, timeInForce: true, iceberg: true},
+	OrderTypeTakeProfit:      {stopPrice: true},
+	OrderTypeTakeProfitLimit: {price: true, stopPrice: true, timeInForce: true, iceberg: true},
+}
+
+// Validate will make sure that order has the fields required by its type,
+// and none of the fields not allowed. It's called before submitting orders.
+func (o *Order) Validate() error {
+	if o.Symbol == zeroSymbol {
+		return fmt.Errorf("order has no symbol")
+	}
+
+	if o.Side != OrderSideBuy && o.Side != OrderSideSell {
+		return fmt.Errorf("'%s' is not a valid order side", o.Side)
+	}
+
+	fields, found := orderTypeFields[o.Type]
+	if !found {
+		return fmt.Errorf("'%s' is not a valid order type", o.Type)
+	}
+
+	if o.ClientOrderID != "" && !validClientOrderID(o.ClientOrderID) {
+		return fmt.Errorf("%s is not a valid client order ID", o.ClientOrderID)
+	}
+
+	values := []struct {
+		name  string
+		value Value
+	}{
+		{"quantity", o.Quantity},
+		{"quote order quantity", o.QuoteOrderQuantity},
+		{"price", o.Price},
+		{"stop price", o.StopPrice},
+		{"iceberg quantity", o.IcebergQuantity},
+	}
+
+	for _, v := range values {
+		if v.value == zeroValue {
+			continue
+		}
+
+		f, err := v.value.Float64Err()
+		if err != nil || f <= 0 {
+			return fmt.Errorf("%s '%s' is not a positive number", v.name, v.value)
+		}
+	}
+
+	if fields.quote && o.Quantity != zeroValue && o.QuoteOrderQuantity != zeroValue {
+		return fmt.Errorf("%s orders can't have both quantity and quote order quantity", o.Type)
+	}
+
+	if o.Quantity == zeroValue && (!fields.quote || o.QuoteOrderQuantity == zeroValue) {
+		return fmt.Errorf("%s orders require quantity", o.Type)
+	}
+
+	if !fields.quote && o.QuoteOrderQuantity != zeroValue {
+		return fmt.Errorf("%s orders can't have quote order quantity", o.Type)
+	}
+
+	if fields.price != (o.Price != zeroValue) {
+		return requiredOrForbidden(o.Type, "price", fields.price)
+	}
+
+	if fields.stopPrice && o.StopPrice == zeroValue && o.TrailingDelta == 0 {
+		return fmt.Errorf("%s orders require stop price or trailing delta", o.Type)
+	}
+
+	if !fields.stopPrice && (o.StopPrice != zeroValue || o.TrailingDelta != 0) {
+		return fmt.Errorf("%s orders can't have stop price or trailing delta", o.Type)
+	}
+
+	if fields.timeInForce != (o.TimeInForce != zeroTimeInForceZero) {
+		return requiredOrForbidden(o.Type, "time in force", fields.timeInForce)
+	}
+
+	if o.IcebergQuantity != zeroValue {
+		if !fields.iceberg {
+			return fmt.Errorf("%s orders can't have iceberg quantity", o.Type)
+		}
+
+		if fields.timeInForce && o.TimeInForce != GTC {
+			return fmt.Errorf("iceberg orders must be GTC")
+		}
+	}
+
+	if o.StrategyType != 0 && o.StrategyType < 1000000 {
+		return fmt.Errorf("strategy type must be 1000000 or above")
+	}
+
+	return nil
+}
+
+// requiredOrForbidden returns an error telling that field is either required
+// or forbidden for typ.
+func requiredOrForbidden(typ OrderType, field string, required bool) error {
+	if required {
+		return fmt.Errorf("%s orders require %s", typ, field)
+	}
+
+	return fmt.Errorf("%s orders can't have %s", typ, field)
+}
@@ -0,0 +1,66 @@
+package binance
+
+import (
+	"testing"
+)
+
+func TestOrderBuilders(t *testing.T) {
+	cases := []struct {
+		name  string
+		build func() (*Order, error)
+		valid bool
+	}{
+		{"limit", func() (*Order, error) { return LimitBuy("BTCUSDT", "1", "100") }, true},
+		{"limit ioc", func() (*Order, error) { return LimitSell("BTCUSDT", "1", "100", WithTimeInForce(IOC)) }, true},
+		{"limit no price", func() (*Order, error) { return LimitBuy("BTCUSDT", "1", "") }, false},
+		{"limit bad price", func() (*Order, error) { return LimitBuy("BTCUSDT", "1", "abc") }, false},
+		{"limit negative quantity", func() (*Order, error) { return LimitBuy("BTCUSDT", "-1", "100") }, false},
+		{"limit trailing", func() (*Order, error) { return LimitBuy("BTCUSDT", "1", "100", WithTrailingDelta(100)) }, false},
+		{"limit no symbol", func() (*Order, error) { return LimitBuy("", "1", "100") }, false},
+		{"market", func() (*Order, error) { return MarketSell("BTCUSDT", "1") }, true},
+		{"market quote", func() (*Order, error) { return MarketBuyQuote("BTCUSDT", "100") }, true},
+		{"market quote sell", func() (*Order, error) { return MarketSellQuote("BTCUSDT", "100") }, true},
+		{"market no quantity", func() (*Order, error) { return MarketBuy("BTCUSDT", "") }, false},
+		{"market time in force", func() (*Order, error) { return MarketBuy("BTCUSDT", "1", WithTimeInForce(GTC)) }, false},
+		{"market iceberg", func() (*Order, error) { return MarketBuy("BTCUSDT", "1", WithIcebergQuantity("0.1")) }, false},
+		{"stop loss", func() (*Order, error) { return StopLoss("BTCUSDT", OrderSideSell, "1", "90") }, true},
+		{"stop loss trailing", func() (*Order, error) {
+			return StopLoss("BTCUSDT", OrderSideSell, "1", "", WithTrailingDelta(200))
+		}, true},
+		{"stop loss no stop", func() (*Order, error) { return StopLoss("BTCUSDT", OrderSideSell, "1", "") }, false},
+		{"stop loss limit", func() (*Order, error) { return StopLossLimit("BTCUSDT", OrderSideSell, "1", "89", "90") }, true},
+		{"stop loss limit no price", func() (*Order, error) { return StopLossLimit("BTCUSDT", OrderSideSell, "1", "", "90") }, false},
+		{"take profit", func() (*Order, error) { return TakeProfit("BTCUSDT", OrderSideSell, "1", "110") }, true},
+		{"take profit limit", func() (*Order, error) {
+			return TakeProfitLimit("BTCUSDT", OrderSideSell, "1", "111", "110", WithIcebergQuantity("0.1"))
+		}, true},
+		{"limit maker", func() (*Order, error) { return LimitMaker("BTCUSDT", OrderSideBuy, "1", "100") }, true},
+		{"limit maker time in force", func() (*Order, error) {
+			return LimitMaker("BTCUSDT", OrderSideBuy, "1", "100", WithTimeInForce(GTC))
+		}, false},
+		{"iceberg", func() (*Order, error) { return Iceberg("BTCUSDT", OrderSideBuy, "10", "100", "1") }, true},
+		{"iceberg ioc", func() (*Order, error) {
+			return Iceberg("BTCUSDT", OrderSideBuy, "10", "100", "1", WithTimeInForce(IOC))
+		}, false},
+		{"strategy", func() (*Order, error) { return MarketBuy("BTCUSDT", "1", WithStrategy(1, 1000000)) }, true},
+		{"bad strategy", func() (*Order, error) { return MarketBuy("BTCUSDT", "1", WithStrategy(1, 1)) }, false},
+		{"bad client order id", func() (*Order, error) { return MarketBuy("BTCUSDT", "1", WithClientOrderID("no spaces")) }, false},
+	}
+
+	for _, c := range cases {
+		order, err := c.build()
+
+		if c.valid && err != nil {
+			t.Errorf("%s: got error: %s", c.name, err.Error())
+		}
+
+		if !c.valid && err == nil {
+			t.Errorf("%s: got no error for %+v", c.name, order)
+		}
+	}
+
+	order, _ := MarketBuyQuote("BTCUSDT", "100", WithSelfTradePrevention(SelfTradePreventionExpireBoth))
+	if order.Type != OrderTypeMarket || order.Quantity != zeroValue || order.QuoteOrderQuantity != "100" || order.SelfTradePreventionMode != SelfTradePreventionExpireBoth {
+		t.Errorf("MarketBuyQuote returned %+v", order)
+	}
+}
@@ -0,0 +1,380 @@
+package binance
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// OrderList describes a list of orders where the execution of one order
+// affects the others. The individual orders are regular orders, and can be
+// queried using OrderStatus().
+type OrderList struct {
+	ID                int             `json:"orderListId"`
+	ContingencyType   ContingencyType `json:"contingencyType"`
+	ListStatusType    ListStatusType  `json:"listStatusType"`
+	ListOrderStatus   ListOrderStatus `json:"listOrderStatus"`
+	ListClientOrderID string          `json:"listClientOrderId"`
+	TransactionTime   Time            `json:"transactionTime"`
+	Symbol            Symbol          `json:"symbol"`
+	Orders            []struct {
+		Symbol        Symbol `json:"symbol"`
+		OrderID       int    `json:"orderId"`
+		ClientOrderID string `json:"clientOrderId"`
+	} `json:"orders"`
+
+	// OrderReports is only set when submitting or canceling a list.
+	OrderReports []Order `json:"orderReports"`
+}
+
+// OCO is a pair of orders where one is canceled when the other executes.
+// Above is the order priced above the market, and must be LIMIT_MAKER,
+// STOP_LOSS(_LIMIT) or TAKE_PROFIT(_LIMIT). Below is the order priced below
+// the market. Only the type, client order ID, prices, trailing delta,
+// iceberg quantity and time in force of the legs are used.
+type OCO struct {
+	Symbol            Symbol
+	Side              OrderSide
+	Quantity          Value
+	ListClientOrderID string
+	Above             *Order
+	Below             *Order
+}
+
+// OTO is a pair of orders where the pending order is placed when the working
+// order is filled.
+type OTO struct {
+	ListClientOrderID string
+	Working           *Order
+	Pending           *Order
+}
+
+// OTOCO is an order triggering an OCO pair when filled. The side and
+// quantity of the OCO pair is taken from PendingAbove.
+type OTOCO struct {
+	ListClientOrderID string
+	Working           *Order
+	PendingAbove      *Order
+	PendingBelow      *Order
+}
+
+// legParams returns the parameters describing a leg in an order list. The
+// parameters are named like the parameters for single orders, prefixed with
+// prefix.
+func legParams(prefix string, leg *Order) []func(url.Values) {
+	params := []func(url.Values){
+		param(prefix+"Type", leg.Type),
+	}
+
+	if leg.ClientOrderID != "" {
+		params = append(params, param(prefix+"ClientOrderId", leg.ClientOrderID))
+	}
+
+	if leg.Price != zeroValue {
+		params = append(params, param(prefix+"Price", leg.Price))
+	}
+
+	if leg.StopPrice != zeroValue {
+		params = append(params, param(prefix+"StopPrice", leg.StopPrice))
+	}
+
+	if leg.TrailingDelta != 0 {
+		params = append(params, param(prefix+"TrailingDelta", leg.TrailingDelta))
+	}
+
+	if leg.IcebergQuantity != zeroValue {
+		params = append(params, param(prefix+"IcebergQty", leg.IcebergQuantity))
+	}
+
+	if leg.TimeInForce != zeroTimeInForceZero {
+		params = append(params, param(prefix+"TimeInForce", leg.TimeInForce))
+	}
+
+	return params
+}
+
+// validateLeg will check leg like a single order for symbol, side and
+// quantity, as the legs of some lists take these from the list.
+func validateLeg(name string, leg *Order, symbol Symbol, side OrderSide, quantity Value) error {
+	order := *leg
+	order.Symbol, order.Side, order.Quantity = symbol, side, quantity
+
+	err := order.Validate()
+	if err != nil {
+		return fmt.Errorf("%s order: %s", name, err.Error())
+	}
+
+	return nil
+}
+
+// orderListLeg is an order in a list along with its parameter prefix.
+type orderListLeg struct {
+	prefix string
+	order  *Order
+}
+
+// submitOrderList will submit an order list. Missing client order IDs are
+// generated.
+func (c *Client) submitOrderList(uri string, listClientOrderID *string, legs []orderListLeg, params ...func(url.Values)) (*OrderList, error) {
+	for _, leg := range legs {
+		if leg.order == nil {
+			return nil, fmt.Errorf("missing %s order", leg.prefix)
+		}
+	}
+
+	if *listClientOrderID == "" {
+		*listClientOrderID = c.NewClientOrderID()
+	}
+
+	params = append(params, param("listClientOrderId", *listClientOrderID))
+
+	for _, leg := range legs {
+		if leg.order.ClientOrderID == "" {
+			leg.order.ClientOrderID = c.NewClientOrderID()
+		}
+
+		params = append(params, legParams(leg.prefix, leg.order)...)
+	}
+
+	var list OrderList
+	err := c.signedCall(&list, "POST", uri, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &list, nil
+}
+
+// SubmitOCO will submit a one-cancels-the-other order pair.
+func (c *Client) SubmitOCO(oco *OCO) (*OrderList, error) {
+	if oco.Above == nil || oco.Below == nil {
+		return nil, errors.New("missing above or below order")
+	}
+
+	for _, leg := range []orderListLeg{{"above", oco.Above}, {"below", oco.Below}} {
+		err := validateLeg(leg.prefix, leg.order, oco.Symbol, oco.Side, oco.Quantity)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return c.submitOrderList("/api/v3/orderList/oco", &oco.ListClientOrderID,
+		[]orderListLeg{
+			{"above", oco.Above},
+			{"below", oco.Below},
+		},
+		param("symbol", oco.Symbol),
+		param("side", oco.Side),
+		param("quantity", oco.Quantity),
+	)
+}
+
+// SubmitOTO will submit a one-triggers-the-other order pair. Both orders
+// must be for the same symbol.
+func (c *Client) SubmitOTO(oto *OTO) (*OrderList, error) {
+	if oto.Working == nil || oto.Pending == nil {
+		return nil, errors.New("missing working or pending order")
+	}
+
+	if oto.Working.Symbol != oto.Pending.Symbol {
+		return nil, errors.New("working and pending order must be for the same symbol")
+	}
+
+	for _, leg := range []orderListLeg{{"working", oto.Working}, {"pending", oto.Pending}} {
+		err := validateLeg(leg.prefix, leg.order, leg.order.Symbol, leg.order.Side, leg.order.Quantity)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return c.submitOrderList("/api/v3/orderList/oto", &oto.ListClientOrderID,
+		[]orderListLeg{
+			{"working", oto.Working},
+			{"pending", oto.Pending},
+		},
+		param("symbol", oto.Working.Symbol),
+		param("workingSide", oto.Working.Side),
+		param("workingQuantity", oto.Working.Quantity),
+		param("pendingSide", oto.Pending.Side),
+		param("pendingQuantity", oto.Pending.Quantity),
+	)
+}
+
+// SubmitOTOCO will submit an order triggering a one-cancels-the-other order
+// pair. All orders must be for the same symbol.
+func (c *Client) SubmitOTOCO(otoco *OTOCO) (*OrderList, error) {
+	if otoco.Working == nil || otoco.PendingAbove == nil || otoco.PendingBelow == nil {
+		return nil, errors.New("missing working or pending order")
+	}
+
+	if otoco.Working.Symbol != otoco.PendingAbove.Symbol || otoco.Working.Symbol != otoco.PendingBelow.Symbol {
+		return nil, errors.New("all orders must be for the same symbol")
+	}
+
+	err := validateLeg("working", otoco.Working, otoco.Working.Symbol, otoco.Working.Side, otoco.Working.Quantity)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, leg := range []orderListLeg{{"pendingAbove", otoco.PendingAbove}, {"pendingBelow", otoco.PendingBelow}} {
+		err = validateLeg(leg.prefix, leg.order, otoco.Working.Symbol, otoco.PendingAbove.Side, otoco.PendingAbove.Quantity)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return c.submitOrderList("/api/v3/orderList/otoco", &otoco.ListClientOrderID,
+		[]orderListLeg{
+			{"working", otoco.Working},
+			{"pendingAbove", otoco.PendingAbove},
+			{"pendingBelow", otoco.PendingBelow},
+		},
+		param("symbol", otoco.Working.Symbol),
+		param("workingSide", otoco.Working.Side),
+		param("workingQuantity", otoco.Working.Quantity),
+		param("pendingSide", otoco.PendingAbove.Side),
+		param("pendingQuantity", otoco.PendingAbove.Quantity),
+	)
+}
+
+// orderListIDParams returns the parameters used for identifying an existing
+// order list. Exactly one of listClientOrderID and id must be set.
+func orderListIDParams(listClientOrderID string, id int) ([]func(url.Values), error) {
+	if listClientOrderID == "" && id == 0 {
+		return nil, errors.New("listClientOrderID and ID empty")
+	}
+
+	if listClientOrderID != "" && id != 0 {
+		return nil, errors.New("listClientOrderID and ID both set")
+	}
+
+	if listClientOrderID != "" {
+		return []func(url.Values){param("origClientOrderId", listClientOrderID)}, nil
+	}
+
+	return []func(url.Values){param("orderListId", id)}, nil
+}
+
+// CancelOrderList cancels all orders in a list.
+func (c *Client) CancelOrderList(symbol Symbol, listClientOrderID string, id int) (*OrderList, error) {
+	params, err := orderListIDParams(listClientOrderID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// The cancel endpoint names the client ID differently than the query
+	// endpoint. Sigh.
+	if listClientOrderID != "" {
+		params = []func(url.Values){param("listClientOrderId", listClientOrderID)}
+	}
+
+	params = append(params, param("symbol", symbol))
+
+	var list OrderList
+	err = c.signedCall(&list, "DELETE", "/api/v3/orderList", params...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &list, nil
+}
+
+// OrderList queries the status of an order list.
+func (c *Client) OrderList(listClientOrderID string, id int) (*OrderList, error) {
+	params, err := orderListIDParams(listClientOrderID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var list OrderList
+	err = c.signedCall(&list, "GET", "/api/v3/orderList", params...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &list, nil
+}
+
+// AllOrderLists will list order lists. You can refine the query with
+// FromID(), StartTime(), EndTime() and Limit(). StartTime() and EndTime()
+// can't be more than 24 hours apart, use OrderListHistory() for walking
+// longer periods.
+func (c *Client) AllOrderLists(options ...QueryFunc) ([]OrderList, error) {
+	var lists []OrderList
+
+	err := c.signedCall(&lists, "GET", "/api/v3/allOrderList", newQuery(options).params())
+	if err != nil {
+		return nil, err
+	}
+
+	return lists, nil
+}
+
+// OpenOrderLists lists the currently open order lists.
+func (c *Client) OpenOrderLists() ([]OrderList, error) {
+	var lists []OrderList
+
+	err := c.signedCall(&lists, "GET", "/api/v3/openOrderList")
+	if err != nil {
+		return nil, err
+	}
+
+	return lists, nil
+}
+
+// OrderListIterator walks all order lists in a period. It's used like
+// bufio.Scanner:
+//
+//	it := client.OrderListHistory(start, end)
+//	for it.Next() {
+//		list := it.OrderList()
+//	}
+//	if it.Err() != nil {
+//		...
+//	}
+type OrderListIterator struct {
+	walker *historyWalker
+	list   OrderList
+}
+
+// orderListPage is a page of order lists.
+type orderListPage []OrderList
+
+func (p orderListPage) Len() int        { return len(p) }
+func (p orderListPage) ID(i int) int64  { return int64(p[i].ID) }
+func (p orderListPage) Time(i int) Time { return p[i].TransactionTime }
+
+// OrderListHistory returns an iterator walking all order lists created from
+// start until end.
+func (c *Client) OrderListHistory(start Time, end Time) *OrderListIterator {
+	fetch := func(options ...QueryFunc) (historyPage, error) {
+		lists, err := c.AllOrderLists(options...)
+
+		return orderListPage(lists), err
+	}
+
+	return &OrderListIterator{
+		walker: newHistoryWalker(start, end, FromID, fetch),
+	}
+}
+
+// Next advances the iterator to the next order list. It returns false when
+// there are no more lists or an error occurred.
+func (it *OrderListIterator) Next() bool {
+	page, i, ok := it.walker.advance()
+	if ok {
+		it.list = page.(orderListPage)[i]
+	}
+
+	return ok
+}
+
+// OrderList returns the current order list.
+func (it *OrderListIterator) OrderList() OrderList {
+	return it.list
+}
+
+// Err returns the error that stopped the iterator, if any.
+func (it *OrderListIterator) Err() error {
+	return it.walker.err
+}
@@ -0,0 +1,573 @@
+package binance
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// orderTransitions lists the statuses an order can move to from each
+// status. An order can stay partially filled while more fills arrive.
+var orderTransitions = map[OrderStatus][]OrderStatus{
+	PendingNew:      {New, PartiallyFilled, Filled, Canceled, Rejected, Expired, ExpiredInMatch},
+	New:             {PartiallyFilled, Filled, Canceled, PendingCancel, Expired, ExpiredInMatch},
+	PartiallyFilled: {PartiallyFilled, Filled, Canceled, PendingCancel, Expired, ExpiredInMatch},
+	PendingCancel:   {Filled, Canceled, Expired},
+}
+
+// ValidTransition returns true if an order can move from status from to
+// status to.
+func ValidTransition(from OrderStatus, to OrderStatus) bool {
+	for _, s := range orderTransitions[from] {
+		if s == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+// TransitionError is returned when an order update would move an order
+// through an illegal status transition.
+type TransitionError struct {
+	ClientOrderID string
+	From          OrderStatus
+	To            OrderStatus
+}
+
+// Error implements error.
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("order %s can't go from %s to %s", e.ClientOrderID, e.From, e.To)
+}
+
+// OrderManagerOption is used for configuring an OrderManager.
+type OrderManagerOption func(*OrderManager)
+
+// OnOrderFill sets a function to call when an order is filled or partially
+// filled. The fills in the response from SubmitOrder() are reported one by
+// one. fill is nil if the fill was found while reconciling, in that case
+// only the order is known.
+func OnOrderFill(f func(order Order, fill *Fill)) OrderManagerOption {
+	return func(m *OrderManager) {
+		m.onFill = f
+	}
+}
+
+// OnOrderCancel sets a function to call when an order is canceled or
+// expires.
+func OnOrderCancel(f func(order Order)) OrderManagerOption {
+	return func(m *OrderManager) {
+		m.onCancel = f
+	}
+}
+
+// OnOrderReject sets a function to call when an order is rejected.
+func OnOrderReject(f func(order Order)) OrderManagerOption {
+	return func(m *OrderManager) {
+		m.onReject = f
+	}
+}
+
+// OnIllegalTransition sets a function to call when an order update would
+// move an order through an illegal status transition.
+func OnIllegalTransition(f func(err *TransitionError)) OrderManagerOption {
+	return func(m *OrderManager) {
+		m.onIllegalTransition = f
+	}
+}
+
+// OnOrderManagerError sets a function to call when the periodic
+// reconciliation fails, an event on the user data stream can't be decoded,
+// or the stream fails for good.
+func OnOrderManagerError(f func(err error)) OrderManagerOption {
+	return func(m *OrderManager) {
+		m.onError = f
+	}
+}
+
+// ReconcileInterval sets how often the manager will compare its state to
+// the state known by Binance. The default is one minute.
+func ReconcileInterval(interval time.Duration) OrderManagerOption {
+	return func(m *OrderManager) {
+		m.reconcileInterval = interval
+	}
+}
+
+// OrderManager keeps the local state of our orders. Orders are updated from
+// execution reports on the user data stream, and reconciled periodically
+// with the open orders as known by Binance, to repair missed events. Updates
+// older than the known state are ignored. Callbacks are called from the
+// goroutine applying the update, and must not block for long.
+type OrderManager struct {
+	client            *Client
+	stream            *UserDataStream
+	done              chan struct{}
+	closeOnce         sync.Once
+	reconcileInterval time.Duration
+
+	onFill              func(Order, *Fill)
+	onCancel            func(Order)
+	onReject            func(Order)
+	onIllegalTransition func(*TransitionError)
+	onError             func(error)
+
+	mu     sync.Mutex
+	orders map[string]*Order
+	err    error
+
+	// finished is when orders reached a final state. Final orders are kept
+	// for finalOrderRetention, to recognize late updates.
+	finished map[string]time.Time
+	now      func() time.Time
+}
+
+// finalOrderRetention is how long final orders are tracked before they are
+// forgotten.
+const finalOrderRetention = 10 * time.Minute
+
+// newOrderManager returns a manager without starting it.
+func newOrderManager(c *Client, options []OrderManagerOption) *OrderManager {
+	m := &OrderManager{
+		client:            c,
+		done:              make(chan struct{}),
+		reconcileInterval: time.Minute,
+		orders:            make(map[string]*Order),
+		finished:          make(map[string]time.Time),
+		now:               time.Now,
+	}
+
+	for _, option := range options {
+		option(m)
+	}
+
+	return m
+}
+
+// OrderManager will open a user data stream and start tracking orders. All
+// currently open orders are tracked from the start. You should call Close()
+// when done.
+func (c *Client) OrderManager(options ...OrderManagerOption) (*OrderManager, error) {
+	m := newOrderManager(c, options)
+
+	stream, err := c.UserDataStream()
+	if err != nil {
+		return nil, err
+	}
+
+	m.stream = stream
+
+	// We open the stream before reconciling, to make sure we don't miss
+	// events in between.
+	err = m.Reconcile()
+	if err != nil {
+		_ = stream.Close()
+		return nil, err
+	}
+
+	go m.readLoop()
+	go m.reconcileLoop()
+
+	return m, nil
+}
+
+// Close will stop tracking orders and close the user data stream. It's safe
+// to call Close() more than once.
+func (m *OrderManager) Close() error {
+	m.closeOnce.Do(func() {
+		close(m.done)
+	})
+
+	return m.stream.Close()
+}
+
+// Err returns the error that stopped the user data stream, if any. After
+// that, orders are only updated by reconciliation.
+func (m *OrderManager) Err() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.err
+}
+
+// Order returns the state of the order with clientOrderID.
+func (m *OrderManager) Order(clientOrderID string) (Order, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	order, found := m.orders[clientOrderID]
+	if !found {
+		return Order{}, false
+	}
+
+	return *order, true
+}
+
+// OpenOrders returns all orders not in a final state.
+func (m *OrderManager) OpenOrders() []Order {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var orders []Order
+	for _, order := range m.orders {
+		if !order.Status.Final() {
+			orders = append(orders, *order)
+		}
+	}
+
+	return orders
+}
+
+// SubmitOrder will submit order and track it. The order is tracked as
+// PendingNew until Binance answers, as execution reports can arrive before
+// the response.
+func (m *OrderManager) SubmitOrder(order *Order) error {
+	if order.ClientOrderID == "" {
+		order.ClientOrderID = m.client.NewClientOrderID()
+	}
+
+	pending := *order
+	pending.Status = PendingNew
+
+	m.mu.Lock()
+	if _, found := m.orders[order.ClientOrderID]; found {
+		m.mu.Unlock()
+		return fmt.Errorf("order %s is already tracked", order.ClientOrderID)
+	}
+
+	m.orders[order.ClientOrderID] = &pending
+	m.mu.Unlock()
+
+	err := m.client.SubmitOrder(order)
+	if err != nil {
+		m.mu.Lock()
+		if o, found := m.orders[order.ClientOrderID]; found && o.Status == PendingNew {
+			delete(m.orders, order.ClientOrderID)
+		}
+		m.mu.Unlock()
+
+		return err
+	}
+
+	next := *order
+
+	// ACK responses don't include the status.
+	if next.Status == "" {
+		next.Status = New
+	}
+
+	return m.update(next, nil, false)
+}
+
+// CancelOrder will cancel the order with clientOrderID.
+func (m *OrderManager) CancelOrder(clientOrderID string) error {
+	order, found := m.Order(clientOrderID)
+	if !found {
+		return fmt.Errorf("order %s is not tracked", clientOrderID)
+	}
+
+	canceled, err := m.client.CancelOrder(order.Symbol, clientOrderID, 0)
+	if err != nil {
+		return err
+	}
+
+	// The response carries the client order ID of the cancel request.
+	canceled.ClientOrderID = clientOrderID
+
+	return m.update(*canceled, nil, false)
+}
+
+// Apply will update the state of an order from an execution report. Orders
+// not known to the manager will be tracked from here. A *TransitionError is
+// returned if the report would move the order through an illegal
+// transition, the state is left unchanged in that case.
+func (m *OrderManager) Apply(report *ExecutionReportEvent) error {
+	var fill *Fill
+
+	if report.ExecutionType == ExecutionTypeTrade {
+		f := report.Fill()
+		fill = &f
+	}
+
+	return m.update(report.Order(), fill, false)
+}
+
+// Reconcile will compare the state of all orders with the state known by
+// Binance. Binance is considered authoritative, so illegal transitions are
+// reported but the state is updated anyway. Orders Binance no longer knows,
+// for example because they're archived, are forgotten. Final orders are
+// forgotten some time after they finished. If some orders can't be looked
+// up, the others are still reconciled and the first error is returned.
+func (m *OrderManager) Reconcile() error {
+	open, err := m.client.OpenOrders(zeroSymbol)
+	if err != nil {
+		return err
+	}
+
+	seen := make(map[string]bool, len(open))
+	for _, order := range open {
+		seen[order.ClientOrderID] = true
+		_ = m.update(order, nil, true)
+	}
+
+	// Orders we believe are open but Binance doesn't have to be looked up
+	// one by one. Orders pending submission are left alone.
+	var missing []Order
+
+	m.mu.Lock()
+	for id, order := range m.orders {
+		if !seen[id] && !order.Status.Final() && order.Status != PendingNew {
+			missing = append(missing, *order)
+		}
+	}
+	m.mu.Unlock()
+
+	var firstErr error
+
+	for _, order := range missing {
+		status, err := m.client.OrderStatus(order.Symbol, order.ClientOrderID, 0)
+		if noSuchOrder(err) {
+			m.forget(order.ClientOrderID)
+			continue
+		}
+
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+
+			continue
+		}
+
+		_ = m.update(*status, nil, true)
+	}
+
+	m.prune()
+
+	return firstErr
+}
+
+// forget will stop tracking the order with clientOrderID.
+func (m *OrderManager) forget(clientOrderID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.orders, clientOrderID)
+	delete(m.finished, clientOrderID)
+}
+
+// prune will forget orders that finished more than finalOrderRetention ago.
+func (m *OrderManager) prune() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	limit := m.now().Add(-finalOrderRetention)
+
+	for id, finished := range m.finished {
+		if finished.Before(limit) {
+			delete(m.orders, id)
+			delete(m.finished, id)
+		}
+	}
+}
+
+// stale returns true if next describes an order state older than, or the
+// same as, current.
+func stale(current *Order, next *Order) bool {
+	if current.Status == next.Status {
+		return next.ExecutedQuantity.Float64() <= current.ExecutedQuantity.Float64()
+	}
+
+	return ValidTransition(next.Status, current.Status) && !ValidTransition(current.Status, next.Status)
+}
+
+// update will move a tracked order to the state of next and call the
+// callbacks. If force is true, illegal transitions are applied anyway.
+func (m *OrderManager) update(next Order, fill *Fill, force bool) error {
+	if next.ClientOrderID == "" {
+		return errors.New("order has no client order ID")
+	}
+
+	m.mu.Lock()
+
+	previous := Order{}
+	current, found := m.orders[next.ClientOrderID]
+	if found {
+		previous = *current
+
+		if stale(current, &next) {
+			m.mu.Unlock()
+			return nil
+		}
+	}
+
+	var err *TransitionError
+	if found && !ValidTransition(current.Status, next.Status) {
+		err = &TransitionError{
+			ClientOrderID: next.ClientOrderID,
+			From:          current.Status,
+			To:            next.Status,
+		}
+	}
+
+	if err == nil || force {
+		// Execution reports don't include the original creation time or
+		// fills from the submit response.
+		if next.Time.IsZero() {
+			next.Time = previous.Time
+		}
+
+		if len(next.Fills) == 0 {
+			next.Fills = previous.Fills
+		}
+
+		m.orders[next.ClientOrderID] = &next
+
+		if next.Status.Final() {
+			if _, found := m.finished[next.ClientOrderID]; !found {
+				m.finished[next.ClientOrderID] = m.now()
+			}
+		}
+	}
+
+	m.mu.Unlock()
+
+	if err != nil {
+		if m.onIllegalTransition != nil {
+			m.onIllegalTransition(err)
+		}
+
+		if !force {
+			return err
+		}
+	}
+
+	// Orders adopted while reconciling may have history we don't know
+	// about, so we only tell about changes from now on.
+	if found || fill != nil {
+		m.notify(previous, next, fill)
+	}
+
+	return nil
+}
+
+// notify will call the callbacks describing the change from previous to
+// next.
+func (m *OrderManager) notify(previous Order, next Order, fill *Fill) {
+	if m.onFill != nil && next.ExecutedQuantity.Float64() > previous.ExecutedQuantity.Float64() {
+		fills := newFills(previous, next)
+
+		switch {
+		case fill != nil:
+			m.onFill(next, fill)
+		case len(fills) > 0:
+			for i := range fills {
+				m.onFill(next, &fills[i])
+			}
+		default:
+			m.onFill(next, nil)
+		}
+	}
+
+	if previous.Status == next.Status {
+		return
+	}
+
+	switch next.Status {
+	case Canceled, Expired, ExpiredInMatch:
+		if m.onCancel != nil {
+			m.onCancel(next)
+		}
+
+	case Rejected:
+		if m.onReject != nil {
+			m.onReject(next)
+		}
+	}
+}
+
+// newFills returns the fills of next not yet executed in previous. Only
+// submit responses carry fills, so this is empty for other updates.
+func newFills(previous Order, next Order) []Fill {
+	executed := previous.ExecutedQuantity.Float64()
+	cumulative := 0.0
+
+	var fills []Fill
+	for _, f := range next.Fills {
+		cumulative += f.Quantity.Float64()
+
+		// The small addition absorbs rounding errors in the sum.
+		if cumulative > executed+1e-9 {
+			fills = append(fills, f)
+		}
+	}
+
+	return fills
+}
+
+// readLoop will apply execution reports from the user data stream until the
+// stream is closed or can't be reconnected. Events that can't be decoded
+// are reported and skipped.
+func (m *OrderManager) readLoop() {
+	for {
+		event, err := m.stream.Read()
+		if _, ok := err.(*EventError); ok {
+			m.report(err)
+			continue
+		}
+
+		if err != nil {
+			m.mu.Lock()
+			m.err = err
+			m.mu.Unlock()
+
+			select {
+			case <-m.done:
+			default:
+				m.report(err)
+			}
+
+			return
+		}
+
+		switch e := event.(type) {
+		case *ExecutionReportEvent:
+			_ = m.Apply(e)
+
+		case *ListenKeyExpiredEvent:
+			// Events could have been lost while reconnecting.
+			m.reconcile()
+		}
+	}
+}
+
+// reconcileLoop will reconcile periodically until the manager is closed.
+func (m *OrderManager) reconcileLoop() {
+	ticker := time.NewTicker(m.reconcileInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.done:
+			return
+		case <-ticker.C:
+		}
+
+		m.reconcile()
+	}
+}
+
+// reconcile will reconcile and report errors to the error callback.
+func (m *OrderManager) reconcile() {
+	err := m.Reconcile()
+	if err != nil {
+		m.report(err)
+	}
+}
+
+// report will call the error callback, if set.
+func (m *OrderManager) report(err error) {
+	if m.onError != nil {
+		m.onError(err)
+	}
+}
@@ -0,0 +1,235 @@
+package binance
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestOrderManagerApply(t *testing.T) {
+	report := func(x ExecutionType, status OrderStatus, last string, cumulative string) *ExecutionReportEvent {
+		return &ExecutionReportEvent{
+			Symbol:                   "ETHBTC",
+			ClientOrderID:            "order1",
+			OrderID:                  1,
+			ExecutionType:            x,
+			Status:                   status,
+			Quantity:                 "1.0",
+			LastExecutedQuantity:     Value(last),
+			CumulativeFilledQuantity: Value(cumulative),
+		}
+	}
+
+	var fills []Value
+	var canceled, illegal int
+
+	m := newOrderManager(nil, []OrderManagerOption{
+		OnOrderFill(func(order Order, fill *Fill) {
+			fills = append(fills, fill.Quantity)
+		}),
+		OnOrderCancel(func(order Order) { canceled++ }),
+		OnIllegalTransition(func(err *TransitionError) { illegal++ }),
+	})
+
+	cases := []struct {
+		report   *ExecutionReportEvent
+		err      bool
+		status   OrderStatus
+		executed Value
+	}{
+		{report(ExecutionTypeNew, New, "0", "0"), false, New, "0"},
+		{report(ExecutionTypeTrade, PartiallyFilled, "0.4", "0.4"), false, PartiallyFilled, "0.4"},
+		// Duplicate, ignored.
+		{report(ExecutionTypeTrade, PartiallyFilled, "0.4", "0.4"), false, PartiallyFilled, "0.4"},
+		{report(ExecutionTypeTrade, PartiallyFilled, "0.2", "0.6"), false, PartiallyFilled, "0.6"},
+		// Late report, ignored.
+		{report(ExecutionTypeNew, New, "0", "0"), false, PartiallyFilled, "0.6"},
+		{report(ExecutionTypeTrade, Filled, "0.4", "1.0"), false, Filled, "1.0"},
+		// Illegal, the order is already filled.
+		{report(ExecutionTypeCanceled, Canceled, "0", "1.0"), true, Filled, "1.0"},
+	}
+
+	for i, c := range cases {
+		err := m.Apply(c.report)
+		if c.err {
+			if _, ok := err.(*TransitionError); !ok {
+				t.Errorf("case %d: expected *TransitionError, got %v", i, err)
+			}
+		} else if err != nil {
+			t.Errorf("case %d: Apply failed: %s", i, err.Error())
+		}
+
+		order, found := m.Order("order1")
+		if !found || order.Status != c.status || order.ExecutedQuantity != c.executed {
+			t.Errorf("case %d: got %s %s, expected %s %s", i, order.Status, order.ExecutedQuantity, c.status, c.executed)
+		}
+	}
+
+	if fmt.Sprint(fills) != "[0.4 0.2 0.4]" {
+		t.Errorf("got fills %v", fills)
+	}
+
+	if canceled != 0 || illegal != 1 {
+		t.Errorf("got %d cancels and %d illegal transitions", canceled, illegal)
+	}
+
+	if len(m.OpenOrders()) != 0 {
+		t.Errorf("filled order still open")
+	}
+}
+
+func TestOrderManagerReconcile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v3/openOrders":
+			fmt.Fprint(w, `[{"symbol":"ETHBTC","orderId":2,"clientOrderId":"order2","status":"PARTIALLY_FILLED","executedQty":"0.5"},{"symbol":"ETHBTC","orderId":3,"clientOrderId":"order3","status":"PARTIALLY_FILLED","executedQty":"0.5"}]`)
+		case "/api/v3/order":
+			id := r.URL.Query().Get("origClientOrderId")
+
+			switch id {
+			case "order4":
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(w, `{"code":-2013,"msg":"Order does not exist."}`)
+				return
+			case "order5":
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+
+			fmt.Fprintf(w, `{"symbol":"ETHBTC","orderId":1,"clientOrderId":"%s","status":"CANCELED","executedQty":"0"}`, id)
+		default:
+			t.Errorf("unexpected request for %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(APIKey("key"), APISecret("secret"), BaseURL(server.URL))
+
+	var filled, canceled []string
+
+	m := newOrderManager(client, []OrderManagerOption{
+		OnOrderFill(func(order Order, fill *Fill) {
+			if fill != nil {
+				t.Errorf("got fill from reconciliation")
+			}
+
+			filled = append(filled, order.ClientOrderID)
+		}),
+		OnOrderCancel(func(order Order) {
+			canceled = append(canceled, order.ClientOrderID)
+		}),
+	})
+
+	for i, id := range []string{"order1", "order2", "order4", "order5"} {
+		err := m.Apply(&ExecutionReportEvent{Symbol: "ETHBTC", ClientOrderID: id, OrderID: i + 1, ExecutionType: ExecutionTypeNew, Status: New})
+		if err != nil {
+			t.Fatalf("Apply failed: %s", err.Error())
+		}
+	}
+
+	// order5 can't be looked up, but the other orders are still
+	// reconciled.
+	err := m.Reconcile()
+	if err == nil {
+		t.Fatalf("Reconcile did not fail")
+	}
+
+	if fmt.Sprint(filled) != "[order2]" || fmt.Sprint(canceled) != "[order1]" {
+		t.Errorf("got fills %v and cancels %v", filled, canceled)
+	}
+
+	// order3 was unknown, and should be tracked from now on. order4 is
+	// unknown to Binance and forgotten.
+	open := m.OpenOrders()
+	if len(open) != 3 {
+		t.Errorf("got open orders %+v", open)
+	}
+
+	if _, found := m.Order("order4"); found {
+		t.Errorf("order4 is still tracked")
+	}
+
+	// The canceled order1 is forgotten after a while.
+	if _, found := m.Order("order1"); !found {
+		t.Errorf("order1 forgotten too soon")
+	}
+
+	m.now = func() time.Time {
+		return time.Now().Add(finalOrderRetention + time.Minute)
+	}
+
+	_ = m.Reconcile()
+
+	if _, found := m.Order("order1"); found {
+		t.Errorf("order1 is still tracked")
+	}
+}
+
+func TestValidTransition(t *testing.T) {
+	cases := []struct {
+		from     OrderStatus
+		to       OrderStatus
+		expected bool
+	}{
+		{New, PartiallyFilled, true},
+		{PartiallyFilled, PartiallyFilled, true},
+		{New, New, false},
+		{Filled, Canceled, false},
+		{Canceled, New, false},
+		{PendingNew, Rejected, true},
+	}
+
+	for _, c := range cases {
+		if ValidTransition(c.from, c.to) != c.expected {
+			t.Errorf("ValidTransition(%s, %s) returned %t", c.from, c.to, !c.expected)
+		}
+	}
+}
+
+func TestOrderManagerSubmitFills(t *testing.T) {
+	var m *OrderManager
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.URL.Query().Get("newClientOrderId")
+
+		// The first trade is reported by the stream before the response.
+		err := m.Apply(&ExecutionReportEvent{Symbol: "ETHBTC", ClientOrderID: id, OrderID: 1, ExecutionType: ExecutionTypeTrade, Status: PartiallyFilled, Quantity: "1.0", LastExecutedQuantity: "0.4", CumulativeFilledQuantity: "0.4"})
+		if err != nil {
+			t.Errorf("Apply failed: %s", err.Error())
+		}
+
+		fmt.Fprintf(w, `{"symbol":"ETHBTC","orderId":1,"clientOrderId":"%s","status":"FILLED","origQty":"1.0","executedQty":"1.0","fills":[{"price":"0.1","qty":"0.4","tradeId":1},{"price":"0.1","qty":"0.6","tradeId":2}]}`, id)
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(APIKey("key"), APISecret("secret"), BaseURL(server.URL))
+
+	var fills []Value
+
+	m = newOrderManager(client, []OrderManagerOption{
+		OnOrderFill(func(order Order, fill *Fill) {
+			if fill == nil {
+				t.Errorf("got fill without details")
+				return
+			}
+
+			fills = append(fills, fill.Quantity)
+		}),
+	})
+
+	order, _ := MarketBuy("ETHBTC", "1.0", WithResponseType(OrderResponseFull))
+
+	err := m.SubmitOrder(order)
+	if err != nil {
+		t.Fatalf("SubmitOrder failed: %s", err.Error())
+	}
+
+	// The last trade arrives after the response, and is already known.
+	_ = m.Apply(&ExecutionReportEvent{Symbol: "ETHBTC", ClientOrderID: order.ClientOrderID, OrderID: 1, ExecutionType: ExecutionTypeTrade, Status: Filled, Quantity: "1.0", LastExecutedQuantity: "0.6", CumulativeFilledQuantity: "1.0"})
+
+	if fmt.Sprint(fills) != "[0.4 0.6]" {
+		t.Errorf("got fills %v", fills)
+	}
+}
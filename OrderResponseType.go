@@ -0,0 +1,21 @@
+package binance
+
+// OrderResponseType decides how much Binance will tell about an order when
+// submitting it.
+type OrderResponseType string
+
+// The different response types. OrderResponseAck will only return the IDs
+// of the order, OrderResponseResult will also return the status and executed
+// quantity, and OrderResponseFull will also return the individual fills.
+const (
+	OrderResponseAck    OrderResponseType = "ACK"
+	OrderResponseResult OrderResponseType = "RESULT"
+	OrderResponseFull   OrderResponseType = "FULL"
+
+	zeroOrderResponseType OrderResponseType = ""
+)
+
+// String implement Stringer.
+func (o OrderResponseType) String() string {
+	return string(o)
+}
@@ -11,12 +11,14 @@ type OrderStatus string
 // The different states of an order.
 const (
 	New             OrderStatus = "NEW"
+	PendingNew      OrderStatus = "PENDING_NEW"
 	PartiallyFilled OrderStatus = "PARTIALLY_FILLED"
 	Filled          OrderStatus = "FILLED"
 	Canceled        OrderStatus = "CANCELED"
 	PendingCancel   OrderStatus = "PENDING_CANCEL"
 	Rejected        OrderStatus = "REJECTED"
 	Expired         OrderStatus = "EXPIRED"
+	ExpiredInMatch  OrderStatus = "EXPIRED_IN_MATCH"
 )
 
 // UnmarshalJSON implements json.Unmarshaler while making sure only enums
@@ -31,7 +33,8 @@ func (o *OrderStatus) UnmarshalJSON(data []byte) error {
 	status := OrderStatus(s)
 
 	switch status {
-	case New, PartiallyFilled, Filled, Canceled, PendingCancel, Rejected, Expired:
+	case New, PendingNew, PartiallyFilled, Filled, Canceled, PendingCancel,
+		Rejected, Expired, ExpiredInMatch:
 		*o = status
 	default:
 		return fmt.Errorf("%s is not a valid order status", s)
@@ -40,6 +43,16 @@ func (o *OrderStatus) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// Final returns true if the order can't change status anymore.
+func (o OrderStatus) Final() bool {
+	switch o {
+	case Filled, Canceled, Rejected, Expired, ExpiredInMatch:
+		return true
+	}
+
+	return false
+}
+
 // String implement Stringer.
 func (o OrderStatus) String() string {
 	return string(o)
@@ -0,0 +1,202 @@
+package binance
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestOrderFullResponse(t *testing.T) {
+	data := `{"symbol":"BTCUSDT","orderId":28,"orderListId":-1,"clientOrderId":"6gCrw2kRUAF9CvJDGP16IP","transactTime":1507725176595,"price":"0.00000000","origQty":"10.00000000","executedQty":"10.00000000","cummulativeQuoteQty":"10.00000000","status":"FILLED","timeInForce":"GTC","type":"MARKET","side":"SELL","fills":[{"price":"4000.00000000","qty":"1.00000000","commission":"4.00000000","commissionAsset":"USDT","tradeId":56},{"price":"3999.00000000","qty":"5.00000000","commission":"19.99500000","commissionAsset":"USDT","tradeId":57}]}`
+
+	order := &Order{
+		Symbol:       "BTCUSDT",
+		Side:         OrderSideSell,
+		Type:         OrderTypeMarket,
+		Quantity:     "10",
+		ResponseType: OrderResponseFull,
+	}
+
+	err := json.Unmarshal([]byte(data), order)
+	if err != nil {
+		t.Fatalf("Unmarshal failed: %s", err.Error())
+	}
+
+	if order.ID != 28 || order.Status != Filled || order.ExecutedQuantity != "10.00000000" || order.TransactionTime.IsZero() {
+		t.Errorf("Order not updated: %+v", order)
+	}
+
+	if len(order.Fills) != 2 || order.Fills[1].TradeID != 57 || order.Fills[1].Commission != "19.99500000" || order.Fills[0].CommissionAsset != "USDT" {
+		t.Errorf("Fills decoded wrong: %+v", order.Fills)
+	}
+
+	if order.ResponseType != OrderResponseFull {
+		t.Errorf("ResponseType was changed to '%s'", order.ResponseType)
+	}
+}
+
+func TestSubmitOrderUnknownExecution(t *testing.T) {
+	cases := []struct {
+		exists          bool
+		retries         int
+		expectedErr     bool
+		expectedSubmits int
+	}{
+		{true, 0, false, 1},
+		{false, 0, true, 1},
+		{false, 2, true, 3},
+	}
+
+	unknownExecutionDelay = time.Millisecond
+	defer func() { unknownExecutionDelay = time.Second }()
+
+	for i, c := range cases {
+		submits := 0
+		var clientOrderIDs []string
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case "POST":
+				submits++
+				clientOrderIDs = append(clientOrderIDs, r.URL.Query().Get("newClientOrderId"))
+				w.WriteHeader(http.StatusServiceUnavailable)
+			case "GET":
+				if !c.exists {
+					w.WriteHeader(http.StatusBadRequest)
+					fmt.Fprint(w, `{"code":-2013,"msg":"Order does not exist."}`)
+					return
+				}
+
+				fmt.Fprintf(w, `{"symbol":"BTCUSDT","orderId":1,"clientOrderId":"%s","status":"NEW"}`, r.URL.Query().Get("origClientOrderId"))
+			}
+		}))
+
+		client, _ := NewClient(APIKey("key"), APISecret("secret"), BaseURL(server.URL), SubmitRetries(c.retries))
+
+		order := &Order{Symbol: "BTCUSDT", Side: OrderSideBuy, Type: OrderTypeMarket, Quantity: "1"}
+		err := client.SubmitOrder(order)
+		server.Close()
+
+		if (err != nil) != c.expectedErr {
+			t.Errorf("case %d: got error %v", i, err)
+		}
+
+		if submits != c.expectedSubmits {
+			t.Errorf("case %d: order submitted %d times, expected %d", i, submits, c.expectedSubmits)
+		}
+
+		for _, id := range clientOrderIDs {
+			if id != order.ClientOrderID {
+				t.Errorf("case %d: submitted with client order ID %s, expected %s", i, id, order.ClientOrderID)
+			}
+		}
+
+		if c.exists && (order.ID != 1 || order.Status != New) {
+			t.Errorf("case %d: order not updated: %+v", i, order)
+		}
+	}
+}
+
+func TestCancelAllOrders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"symbol":"BTCUSDT","origClientOrderId":"E6APeyTJvkMvLMYMqu1KQ4","orderId":11,"orderListId":-1,"clientOrderId":"pXLV6Hz6mprAcVYpVMTGgx","price":"0.089853","origQty":"0.178622","executedQty":"0.000000","cummulativeQuoteQty":"0.000000","status":"CANCELED","timeInForce":"GTC","type":"LIMIT","side":"BUY"},{"orderListId":1929,"contingencyType":"OCO","listStatusType":"ALL_DONE","listOrderStatus":"ALL_DONE","listClientOrderId":"2inzWQdDvZLHbbAmAozX2N","transactionTime":1585230948299,"symbol":"BTCUSDT","orders":[{"symbol":"BTCUSDT","orderId":20,"clientOrderId":"CwOOIPHSmYywx6jZX77TdL"},{"symbol":"BTCUSDT","orderId":21,"clientOrderId":"461cPg51vQjV3zIMOXNz39"}]}]`)
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(APIKey("key"), APISecret("secret"), BaseURL(server.URL))
+
+	orders, lists, err := client.CancelAllOrders("BTCUSDT")
+	if err != nil {
+		t.Fatalf("CancelAllOrders failed: %s", err.Error())
+	}
+
+	if len(orders) != 1 || orders[0].ID != 11 || orders[0].Status != Canceled {
+		t.Errorf("Got wrong orders: %+v", orders)
+	}
+
+	if len(lists) != 1 || lists[0].ID != 1929 || lists[0].ContingencyType != ContingencyTypeOCO || len(lists[0].Orders) != 2 {
+		t.Errorf("Got wrong lists: %+v", lists)
+	}
+}
+
+func TestCancelReplaceOrderPartialFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("cancelReplaceMode") != "ALLOW_FAILURE" || r.URL.Query().Get("cancelOrderId") != "9" {
+			t.Errorf("Got wrong query: %s", r.URL.RawQuery)
+		}
+
+		w.WriteHeader(http.StatusConflict)
+		fmt.Fprint(w, `{"code":-2021,"msg":"Order cancel-replace partially failed.","data":{"cancelResult":"FAILURE","newOrderResult":"SUCCESS","cancelResponse":{"code":-2011,"msg":"Unknown order sent."},"newOrderResponse":{"symbol":"BTCUSDT","orderId":12,"orderListId":-1,"clientOrderId":"bX5wROblo6YeDwa9iTLeyY","transactTime":1660813156959,"price":"23416.10000000","origQty":"0.00847000","executedQty":"0.00000000","cummulativeQuoteQty":"0.00000000","status":"NEW","timeInForce":"GTC","type":"LIMIT","side":"SELL"}}}`)
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(APIKey("key"), APISecret("secret"), BaseURL(server.URL))
+
+	order := &Order{Symbol: "BTCUSDT", Side: OrderSideSell, Type: OrderTypeLimit, TimeInForce: GTC, Quantity: "0.00847000", Price: "23416.10000000"}
+
+	result, err := client.CancelReplaceOrder(CancelReplaceAllowFailure, "", 9, order)
+
+	apiErr, ok := err.(*APIError)
+	if !ok || apiErr.Code != ErrorCodeCancelReplacePartiallyFailed {
+		t.Fatalf("Got wrong error: %v", err)
+	}
+
+	if result == nil {
+		t.Fatalf("No result returned")
+	}
+
+	if result.CancelResult != CancelReplaceFailure || result.CancelError == nil || result.CancelError.Code != -2011 || result.Canceled != nil {
+		t.Errorf("Cancel half decoded wrong: %+v", result)
+	}
+
+	if result.NewOrderResult != CancelReplaceSuccess || result.NewOrder == nil || result.NewOrderError != nil {
+		t.Errorf("New order half decoded wrong: %+v", result)
+	}
+
+	if order.ID != 12 || order.Status != New {
+		t.Errorf("Order not updated: %+v", order)
+	}
+}
+
+func TestSubmitOCOLegs(t *testing.T) {
+	var query string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		fmt.Fprint(w, `{"orderListId":1,"contingencyType":"OCO"}`)
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(APIKey("key"), APISecret("secret"), BaseURL(server.URL))
+
+	above, _ := LimitMaker("BTCUSDT", OrderSideSell, "1", "110")
+	below, err := StopLoss("BTCUSDT", OrderSideSell, "1", "", WithTrailingDelta(200))
+	if err != nil {
+		t.Fatalf("StopLoss failed: %s", err.Error())
+	}
+
+	oco := &OCO{Symbol: "BTCUSDT", Side: OrderSideSell, Quantity: "1", Above: above, Below: below}
+
+	_, err = client.SubmitOCO(oco)
+	if err != nil {
+		t.Fatalf("SubmitOCO failed: %s", err.Error())
+	}
+
+	values, _ := url.ParseQuery(query)
+	if values.Get("belowTrailingDelta") != "200" || values.Get("belowType") != "STOP_LOSS" {
+		t.Errorf("got query %s", query)
+	}
+
+	// A stop without stop price or trailing delta is never sent.
+	query = ""
+	oco.Below = &Order{Type: OrderTypeStopLoss}
+
+	_, err = client.SubmitOCO(oco)
+	if err == nil || query != "" {
+		t.Errorf("invalid leg returned %v, sent %s", err, query)
+	}
+}
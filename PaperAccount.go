@@ -0,0 +1,854 @@
+package binance
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// PaperAccount is a simulated account used for paper trading. It's given to
+// a Client by PaperTrading(), and will answer the account and order endpoints
+// itself, while market data is fetched from Binance as usual. Orders are
+// matched against trade prices fed to Tick(), typically from a TradeStream
+// or a replayed feed, and against the best bid and ask fed to Quote(),
+// typically from a BookTickerStream. When the best bid and ask are known,
+// buys are matched against the ask and sells against the bid. Otherwise the
+// last trade price is used.
+//
+// The simulation is simple: Orders are filled completely when the price
+// crosses the limit or stop price, no partial fills are simulated, and the
+// quantity in the order book is not taken into account. Iceberg quantities
+// and trailing stops are not supported. The user data stream and the
+// websocket API are not simulated.
+type PaperAccount struct {
+	transport       http.RoundTripper
+	symbols         map[Symbol]SymbolInfo
+	makerCommission float64
+	takerCommission float64
+	latency         time.Duration
+
+	mu          sync.Mutex
+	balances    map[string]*paperBalance
+	orders      []*paperOrder
+	trades      []TradeOrder
+	prices      map[Symbol]float64
+	books       map[Symbol]paperBook
+	now         Time
+	nextOrderID int
+	nextTradeID int64
+}
+
+// paperBalance is the balance of a single asset.
+type paperBalance struct {
+	free   float64
+	locked float64
+}
+
+// paperBook is the best bid and ask of a symbol.
+type paperBook struct {
+	bid float64
+	ask float64
+}
+
+// paperOrder is an order along with the simulation state.
+type paperOrder struct {
+	Order
+
+	base  string
+	quote string
+
+	// locked is the amount locked for the order. It's locked in the quote
+	// asset for buy orders and the base asset for sell orders.
+	locked float64
+
+	// triggered is set when the stop price of a stop order is reached.
+	triggered bool
+}
+
+// Errors returned by the paper account. The codes match the codes used by
+// Binance.
+var (
+	paperErrUnsupported   = &APIError{Code: -1020, Message: "This operation is not supported by the paper account."}
+	paperErrInvalidSymbol = &APIError{Code: -1121, Message: "Invalid symbol."}
+	paperErrInsufficient  = &APIError{Code: -2010, Message: "Account has insufficient balance for requested action."}
+	paperErrDuplicate     = &APIError{Code: -2010, Message: "Duplicate order sent."}
+	paperErrWouldTake     = &APIError{Code: -2010, Message: "Order would immediately match and take."}
+	paperErrWouldTrigger  = &APIError{Code: -2010, Message: "Order would trigger immediately."}
+	paperErrNoPrice       = &APIError{Code: -2010, Message: "No price known for symbol."}
+	paperErrUnknownOrder  = &APIError{Code: -2011, Message: "Unknown order sent."}
+	paperErrNoSuchOrder   = &APIError{Code: ErrorCodeNoSuchOrder, Message: "Order does not exist."}
+)
+
+// paperHandlers lists the endpoints answered by the paper account.
+var paperHandlers = map[string]func(*PaperAccount, url.Values) (interface{}, error){
+	"POST /api/v3/order":      (*PaperAccount).submitOrder,
+	"POST /api/v3/order/test": (*PaperAccount).testOrder,
+	"DELETE /api/v3/order":    (*PaperAccount).cancelOrder,
+	"GET /api/v3/order":       (*PaperAccount).orderStatus,
+	"GET /api/v3/openOrders":  (*PaperAccount).openOrders,
+	"GET /api/v3/allOrders":   (*PaperAccount).allOrders,
+	"GET /api/v3/account":     (*PaperAccount).accountInfo,
+	"GET /api/v3/myTrades":    (*PaperAccount).myTrades,
+}
+
+// PaperOption is used for configuring a PaperAccount.
+type PaperOption func(*PaperAccount)
+
+// PaperBalance sets the initial free balance of asset.
+func PaperBalance(asset string, amount Value) PaperOption {
+	return func(a *PaperAccount) {
+		a.balance(asset).free = amount.Float64()
+	}
+}
+
+// PaperSymbols sets the symbols that can be traded. The symbols are usually
+// taken from ExchangeInfo().
+func PaperSymbols(symbols ...SymbolInfo) PaperOption {
+	return func(a *PaperAccount) {
+		for _, s := range symbols {
+			a.symbols[Symbol(s.Symbol.UpperCase())] = s
+		}
+	}
+}
+
+// PaperCommission sets the commission rates for makers and takers. 0.001
+// means 0.1%. The default is no commission.
+func PaperCommission(maker float64, taker float64) PaperOption {
+	return func(a *PaperAccount) {
+		a.makerCommission = maker
+		a.takerCommission = taker
+	}
+}
+
+// PaperLatency will delay all answers from the paper account by latency.
+func PaperLatency(latency time.Duration) PaperOption {
+	return func(a *PaperAccount) {
+		a.latency = latency
+	}
+}
+
+// NewPaperAccount returns a new paper account. It should be passed to a
+// client using PaperTrading().
+func NewPaperAccount(options ...PaperOption) *PaperAccount {
+	a := &PaperAccount{
+		transport: http.DefaultTransport,
+		symbols:   make(map[Symbol]SymbolInfo),
+		balances:  make(map[string]*paperBalance),
+		prices:    make(map[Symbol]float64),
+		books:     make(map[Symbol]paperBook),
+	}
+
+	for _, option := range options {
+		option(a)
+	}
+
+	return a
+}
+
+// PaperTrading will make the client use account for all account and order
+// endpoints. Other requests are sent using the HTTP client set by
+// HTTPClient(), whichever order the options are given in. Signed requests
+// the paper account can't answer are refused, and never reach Binance. If no
+// credentials are set, dummy credentials are used.
+func PaperTrading(account *PaperAccount) func(*Client) {
+	return func(c *Client) {
+		c.paper = account
+
+		if c.apiKey == "" {
+			c.apiKey = "paper"
+		}
+
+		if c.apiSecret == "" && c.ed25519Key == nil {
+			c.apiSecret = "paper"
+		}
+	}
+}
+
+// RoundTrip implements http.RoundTripper. Requests not answered by the
+// account are sent using http.DefaultTransport.
+func (a *PaperAccount) RoundTrip(req *http.Request) (*http.Response, error) {
+	return a.serve(req, a.transport.RoundTrip)
+}
+
+// serve will answer req, or pass it on to next if it's not an account or
+// order request.
+func (a *PaperAccount) serve(req *http.Request, next func(*http.Request) (*http.Response, error)) (*http.Response, error) {
+	values := req.URL.Query()
+
+	handler, found := paperHandlers[req.Method+" "+req.URL.Path]
+	if !found {
+		// Signed requests we don't know how to answer must never reach
+		// the real account.
+		if values.Get("signature") != "" {
+			return paperResponse(req, http.StatusBadRequest, paperErrUnsupported)
+		}
+
+		return next(req)
+	}
+
+	time.Sleep(a.latency)
+
+	a.mu.Lock()
+	result, err := handler(a, values)
+	a.mu.Unlock()
+
+	if err != nil {
+		return paperResponse(req, http.StatusBadRequest, err)
+	}
+
+	return paperResponse(req, http.StatusOK, result)
+}
+
+// paperResponse returns a response with status and body encoded as JSON.
+func paperResponse(req *http.Request, status int, body interface{}) (*http.Response, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Response{
+		Status:        strconv.Itoa(status) + " " + http.StatusText(status),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(data)),
+		ContentLength: int64(len(data)),
+		Request:       req,
+	}, nil
+}
+
+// Tick will tell the account that symbol traded at price at t. Open orders
+// for symbol are matched against the price. t is used as the time of the
+// account from now on, to allow replaying old feeds. If t is zero, the
+// current time is used.
+func (a *PaperAccount) Tick(symbol Symbol, price Value, t Time) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	symbol = Symbol(symbol.UpperCase())
+	p := price.Float64()
+
+	a.prices[symbol] = p
+	a.now = t
+
+	for _, o := range a.orders {
+		if o.Symbol == symbol && !o.Status.Final() {
+			a.match(o, p, false)
+		}
+	}
+}
+
+// Quote will tell the account the best bid and ask of symbol at t. Open
+// orders for symbol are matched against them, buys against the ask and
+// sells against the bid. Like on Binance, stop orders are only triggered by
+// trades fed to Tick(). t is used like in Tick().
+func (a *PaperAccount) Quote(symbol Symbol, best *BestPrice, t Time) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	symbol = Symbol(symbol.UpperCase())
+
+	a.books[symbol] = paperBook{
+		bid: best.Bid.Price.Float64(),
+		ask: best.Ask.Price.Float64(),
+	}
+	a.now = t
+
+	for _, o := range a.orders {
+		// Stops are only triggered by trades, see Tick().
+		if o.Symbol != symbol || o.Status.Final() || (isStop(o.Type) && !o.triggered) {
+			continue
+		}
+
+		if price, known := a.price(symbol, o.Side); known {
+			a.match(o, price, false)
+		}
+	}
+}
+
+// FollowBookTicker will feed the best bid and ask from stream to Quote()
+// until reading from the stream fails.
+func (a *PaperAccount) FollowBookTicker(stream *BookTickerStream) error {
+	for {
+		event, err := stream.Read()
+		if err != nil {
+			return err
+		}
+
+		a.Quote(event.Symbol, event.BestPrice(), Time{})
+	}
+}
+
+// price returns the price an order for symbol on side would trade at. This
+// is the best ask for buys and the best bid for sells, if known, or else
+// the last trade price.
+func (a *PaperAccount) price(symbol Symbol, side OrderSide) (float64, bool) {
+	book := a.books[symbol]
+
+	price := book.ask
+	if side == OrderSideSell {
+		price = book.bid
+	}
+
+	if price > 0 {
+		return price, true
+	}
+
+	price, known := a.prices[symbol]
+
+	return price, known
+}
+
+// FollowTrades will feed trades from stream to Tick() until reading from
+// the stream fails.
+func (a *PaperAccount) FollowTrades(stream *TradeStream) error {
+	for {
+		trade, err := stream.Read()
+		if err != nil {
+			return err
+		}
+
+		a.Tick(trade.Symbol, trade.Price, trade.Timestamp)
+	}
+}
+
+// time returns the current time of the account.
+func (a *PaperAccount) time() Time {
+	if a.now.IsZero() {
+		return FromTime(time.Now())
+	}
+
+	return a.now
+}
+
+// balance returns the balance of asset.
+func (a *PaperAccount) balance(asset string) *paperBalance {
+	b, found := a.balances[asset]
+	if !found {
+		b = &paperBalance{}
+		a.balances[asset] = b
+	}
+
+	return b
+}
+
+// paperValue formats f like Binance formats values.
+func paperValue(f float64) Value {
+	return Value(strconv.FormatFloat(f, 'f', 8, 64))
+}
+
+// paperEpsilon is the tolerance used when comparing balances, to absorb
+// rounding errors.
+const paperEpsilon = 1e-9
+
+// parseOrder returns a new order from the parameters in values.
+func (a *PaperAccount) parseOrder(values url.Values) (*paperOrder, error) {
+	order := Order{
+		Symbol:                  Symbol(strings.ToUpper(values.Get("symbol"))),
+		Side:                    OrderSide(values.Get("side")),
+		Type:                    OrderType(values.Get("type")),
+		TimeInForce:             TimeInForce(values.Get("timeInForce")),
+		Quantity:                Value(values.Get("quantity")),
+		QuoteOrderQuantity:      Value(values.Get("quoteOrderQty")),
+		Price:                   Value(values.Get("price")),
+		StopPrice:               Value(values.Get("stopPrice")),
+		IcebergQuantity:         Value(values.Get("icebergQty")),
+		ClientOrderID:           values.Get("newClientOrderId"),
+		SelfTradePreventionMode: SelfTradePreventionMode(values.Get("selfTradePreventionMode")),
+	}
+
+	if values.Get("trailingDelta") != "" {
+		return nil, paperErrUnsupported
+	}
+
+	err := order.Validate()
+	if err != nil {
+		return nil, &APIError{Code: -1102, Message: err.Error()}
+	}
+
+	info, found := a.symbols[order.Symbol]
+	if !found {
+		return nil, paperErrInvalidSymbol
+	}
+
+	// Binance fills in these for all orders.
+	if order.TimeInForce == zeroTimeInForceZero {
+		order.TimeInForce = GTC
+	}
+
+	if order.SelfTradePreventionMode == zeroSelfTradePreventionMode {
+		order.SelfTradePreventionMode = SelfTradePreventionNone
+	}
+
+	return &paperOrder{
+		Order: order,
+		base:  info.BaseAsset,
+		quote: info.QuoteAsset,
+	}, nil
+}
+
+// submitOrder answers POST /api/v3/order.
+func (a *PaperAccount) submitOrder(values url.Values) (interface{}, error) {
+	o, err := a.parseOrder(values)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, other := range a.orders {
+		if other.ClientOrderID == o.ClientOrderID && !other.Status.Final() {
+			return nil, paperErrDuplicate
+		}
+	}
+
+	price, known := a.price(o.Symbol, o.Side)
+
+	// Stops are triggered by the last trade price.
+	if isStop(o.Type) {
+		price, known = a.prices[o.Symbol]
+	}
+
+	switch o.Type {
+	case OrderTypeMarket:
+		if !known {
+			return nil, paperErrNoPrice
+		}
+
+	case OrderTypeLimit:
+		// IOC and FOK orders must be matched right away.
+		if !known && o.TimeInForce != GTC {
+			return nil, paperErrNoPrice
+		}
+
+	case OrderTypeLimitMaker:
+		if known && marketable(o, price) {
+			return nil, paperErrWouldTake
+		}
+
+	case OrderTypeStopLoss, OrderTypeStopLossLimit, OrderTypeTakeProfit, OrderTypeTakeProfitLimit:
+		if known && stopReached(o, price) {
+			return nil, paperErrWouldTrigger
+		}
+	}
+
+	// Sell orders lock the base quantity, buy orders lock the quote needed
+	// at the limit or market price. Buy orders without a known price are
+	// checked when filled.
+	lockAsset, lock := o.quote, 0.0
+	switch {
+	case o.Side == OrderSideSell && o.Quantity == zeroValue:
+		lockAsset, lock = o.base, o.QuoteOrderQuantity.Float64()/price
+	case o.Side == OrderSideSell:
+		lockAsset, lock = o.base, o.Quantity.Float64()
+	case o.Price != zeroValue:
+		lock = o.Quantity.Float64() * o.Price.Float64()
+	case o.Type == OrderTypeMarket && o.Quantity == zeroValue:
+		lock = o.QuoteOrderQuantity.Float64()
+	case o.Type == OrderTypeMarket:
+		lock = o.Quantity.Float64() * price
+	}
+
+	b := a.balance(lockAsset)
+	if b.free+paperEpsilon < lock {
+		return nil, paperErrInsufficient
+	}
+
+	b.free -= lock
+	b.locked += lock
+	o.locked = lock
+
+	now := a.time()
+
+	a.nextOrderID++
+	o.ID = a.nextOrderID
+	o.OrderListID = -1
+	o.Status = New
+	o.ExecutedQuantity = paperValue(0)
+	o.CummulativeQuoteQuantity = paperValue(0)
+	o.Time = now
+	o.Updated = now
+	o.Working = !isStop(o.Type)
+
+	a.orders = append(a.orders, o)
+
+	response := o.Order
+	response.TransactionTime = now
+
+	if known {
+		fill := a.match(o, price, true)
+		if fill != nil {
+			response.Fills = []Fill{*fill}
+		}
+
+		response.Status = o.Status
+		response.ExecutedQuantity = o.ExecutedQuantity
+		response.CummulativeQuoteQuantity = o.CummulativeQuoteQuantity
+		response.Working = o.Working
+	}
+
+	return &response, nil
+}
+
+// testOrder answers POST /api/v3/order/test.
+func (a *PaperAccount) testOrder(values url.Values) (interface{}, error) {
+	_, err := a.parseOrder(values)
+	if err != nil {
+		return nil, err
+	}
+
+	return struct{}{}, nil
+}
+
+// findOrder returns the order identified by values.
+func (a *PaperAccount) findOrder(values url.Values, clientIDKey string) *paperOrder {
+	symbol := Symbol(strings.ToUpper(values.Get("symbol")))
+	id, _ := strconv.Atoi(values.Get("orderId"))
+	clientOrderID := values.Get(clientIDKey)
+
+	// Client order IDs can be reused once an order is done, so we look at
+	// the latest orders first.
+	for i := len(a.orders) - 1; i >= 0; i-- {
+		o := a.orders[i]
+
+		if o.Symbol != symbol {
+			continue
+		}
+
+		if (id != 0 && o.ID == id) || (clientOrderID != "" && o.ClientOrderID == clientOrderID) {
+			return o
+		}
+	}
+
+	return nil
+}
+
+// cancelOrder answers DELETE /api/v3/order.
+func (a *PaperAccount) cancelOrder(values url.Values) (interface{}, error) {
+	o := a.findOrder(values, "origClientOrderId")
+	if o == nil || o.Status.Final() {
+		return nil, paperErrUnknownOrder
+	}
+
+	a.finish(o, Canceled)
+
+	return &o.Order, nil
+}
+
+// orderStatus answers GET /api/v3/order.
+func (a *PaperAccount) orderStatus(values url.Values) (interface{}, error) {
+	o := a.findOrder(values, "origClientOrderId")
+	if o == nil {
+		return nil, paperErrNoSuchOrder
+	}
+
+	return &o.Order, nil
+}
+
+// openOrders answers GET /api/v3/openOrders.
+func (a *PaperAccount) openOrders(values url.Values) (interface{}, error) {
+	symbol := Symbol(strings.ToUpper(values.Get("symbol")))
+
+	orders := []Order{}
+	for _, o := range a.orders {
+		if !o.Status.Final() && (symbol == zeroSymbol || o.Symbol == symbol) {
+			orders = append(orders, o.Order)
+		}
+	}
+
+	return orders, nil
+}
+
+// allOrders answers GET /api/v3/allOrders.
+func (a *PaperAccount) allOrders(values url.Values) (interface{}, error) {
+	symbol := Symbol(strings.ToUpper(values.Get("symbol")))
+
+	var ids []int64
+	var times []Time
+	var matching []Order
+
+	for _, o := range a.orders {
+		if o.Symbol == symbol {
+			ids = append(ids, int64(o.ID))
+			times = append(times, o.Time)
+			matching = append(matching, o.Order)
+		}
+	}
+
+	orders := []Order{}
+	for _, i := range paperPage(values, "orderId", ids, times) {
+		orders = append(orders, matching[i])
+	}
+
+	return orders, nil
+}
+
+// accountInfo answers GET /api/v3/account.
+func (a *PaperAccount) accountInfo(values url.Values) (interface{}, error) {
+	info := &AccountInfo{
+		MakerCommission: int(math.Round(a.makerCommission * 10000)),
+		TakerCommission: int(math.Round(a.takerCommission * 10000)),
+		CommissionRates: CommissionRates{
+			Maker:  paperValue(a.makerCommission),
+			Taker:  paperValue(a.takerCommission),
+			Buyer:  paperValue(0),
+			Seller: paperValue(0),
+		},
+		CanTrade:    true,
+		UpdateTime:  a.time(),
+		AccountType: "SPOT",
+		Permissions: []string{"SPOT"},
+	}
+
+	assets := make([]string, 0, len(a.balances))
+	for asset := range a.balances {
+		assets = append(assets, asset)
+	}
+
+	sort.Strings(assets)
+
+	for _, asset := range assets {
+		b := a.balances[asset]
+
+		info.Balances = append(info.Balances, Balance{
+			Asset:  asset,
+			Free:   paperValue(b.free),
+			Locked: paperValue(b.locked),
+		})
+	}
+
+	return info, nil
+}
+
+// myTrades answers GET /api/v3/myTrades.
+func (a *PaperAccount) myTrades(values url.Values) (interface{}, error) {
+	symbol := Symbol(strings.ToUpper(values.Get("symbol")))
+	orderID, _ := strconv.ParseInt(values.Get("orderId"), 10, 64)
+
+	var ids []int64
+	var times []Time
+	var matching []TradeOrder
+
+	for _, t := range a.trades {
+		if t.Symbol == symbol && (orderID == 0 || t.OrderID == orderID) {
+			ids = append(ids, t.ID)
+			times = append(times, t.TimeStamp)
+			matching = append(matching, t)
+		}
+	}
+
+	trades := []TradeOrder{}
+	for _, i := range paperPage(values, "fromId", ids, times) {
+		trades = append(trades, matching[i])
+	}
+
+	return trades, nil
+}
+
+// paperPage returns the indexes of the objects selected by the history query
+// in values. ids and times describe the objects in ascending order, and
+// idKey is the parameter used for starting from an ID. Like Binance, the
+// most recent objects are returned if neither an ID or a time is given.
+func paperPage(values url.Values, idKey string, ids []int64, times []Time) []int {
+	limit, err := strconv.Atoi(values.Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = 500
+	}
+
+	fromID, _ := strconv.ParseInt(values.Get(idKey), 10, 64)
+	startTime, _ := strconv.ParseInt(values.Get("startTime"), 10, 64)
+	endTime, _ := strconv.ParseInt(values.Get("endTime"), 10, 64)
+
+	var selected []int
+	for i := range ids {
+		ms := times[i].UnixNano() / int64(time.Millisecond)
+
+		if ids[i] < fromID || (startTime != 0 && ms < startTime) || (endTime != 0 && ms > endTime) {
+			continue
+		}
+
+		selected = append(selected, i)
+	}
+
+	if len(selected) <= limit {
+		return selected
+	}
+
+	if fromID == 0 && startTime == 0 && endTime == 0 {
+		return selected[len(selected)-limit:]
+	}
+
+	return selected[:limit]
+}
+
+// isStop returns true if typ is triggered by a stop price.
+func isStop(typ OrderType) bool {
+	switch typ {
+	case OrderTypeStopLoss, OrderTypeStopLossLimit, OrderTypeTakeProfit, OrderTypeTakeProfitLimit:
+		return true
+	}
+
+	return false
+}
+
+// stopReached returns true if the stop price of o is reached at price.
+func stopReached(o *paperOrder, price float64) bool {
+	stop := o.StopPrice.Float64()
+
+	// Stop losses trigger when the price moves against us, take profits
+	// when it moves our way.
+	rising := o.Side == OrderSideBuy
+	if o.Type == OrderTypeTakeProfit || o.Type == OrderTypeTakeProfitLimit {
+		rising = !rising
+	}
+
+	if rising {
+		return price >= stop
+	}
+
+	return price <= stop
+}
+
+// marketable returns true if the limit order o would match at price.
+func marketable(o *paperOrder, price float64) bool {
+	limit := o.Price.Float64()
+
+	if o.Side == OrderSideBuy {
+		return price <= limit
+	}
+
+	return price >= limit
+}
+
+// match will match o against price, and fill it if possible. placing is
+// true when o was just submitted. The fill is returned, if any.
+func (a *PaperAccount) match(o *paperOrder, price float64, placing bool) *Fill {
+	taker := placing
+
+	if isStop(o.Type) && !o.triggered {
+		if !stopReached(o, price) {
+			return nil
+		}
+
+		o.triggered = true
+		o.Working = true
+		o.Updated = a.time()
+		taker = true
+	}
+
+	switch o.Type {
+	case OrderTypeMarket, OrderTypeStopLoss, OrderTypeTakeProfit:
+		return a.fill(o, price, false)
+	}
+
+	// IOC and FOK orders expire if not filled when placed or triggered.
+	if !marketable(o, price) {
+		if taker && o.TimeInForce != GTC {
+			a.finish(o, Expired)
+		}
+
+		return nil
+	}
+
+	// Takers get the market price, makers get their limit price.
+	if taker {
+		return a.fill(o, price, false)
+	}
+
+	return a.fill(o, o.Price.Float64(), true)
+}
+
+// fill will fill o completely at price. If the balance is insufficient, the
+// order expires.
+func (a *PaperAccount) fill(o *paperOrder, price float64, maker bool) *Fill {
+	quantity := o.Quantity.Float64()
+	if o.Quantity == zeroValue {
+		quantity = o.QuoteOrderQuantity.Float64() / price
+	}
+
+	quote := quantity * price
+
+	payAsset, payAmount := o.quote, quote
+	receiveAsset, receiveAmount := o.base, quantity
+	if o.Side == OrderSideSell {
+		payAsset, payAmount = o.base, quantity
+		receiveAsset, receiveAmount = o.quote, quote
+	}
+
+	pay := a.balance(payAsset)
+	if pay.free+o.locked+paperEpsilon < payAmount {
+		a.finish(o, Expired)
+		return nil
+	}
+
+	rate := a.takerCommission
+	if maker {
+		rate = a.makerCommission
+	}
+
+	commission := receiveAmount * rate
+
+	pay.locked -= o.locked
+	pay.free -= payAmount - o.locked
+	o.locked = 0
+
+	a.balance(receiveAsset).free += receiveAmount - commission
+
+	now := a.time()
+
+	a.nextTradeID++
+	trade := TradeOrder{
+		Symbol:          o.Symbol,
+		ID:              a.nextTradeID,
+		OrderID:         int64(o.ID),
+		OrderListID:     -1,
+		Price:           paperValue(price),
+		Quantity:        paperValue(quantity),
+		QuoteQuantity:   paperValue(quote),
+		Commission:      paperValue(commission),
+		CommissionAsset: receiveAsset,
+		TimeStamp:       now,
+		IsBuyer:         o.Side == OrderSideBuy,
+		IsMaker:         maker,
+		IsBestMatch:     true,
+	}
+
+	a.trades = append(a.trades, trade)
+
+	o.Status = Filled
+	o.ExecutedQuantity = trade.Quantity
+	o.CummulativeQuoteQuantity = trade.QuoteQuantity
+	o.Updated = now
+
+	return &Fill{
+		Price:           trade.Price,
+		Quantity:        trade.Quantity,
+		Commission:      trade.Commission,
+		CommissionAsset: trade.CommissionAsset,
+		TradeID:         trade.ID,
+	}
+}
+
+// finish will end o with status and release the locked funds.
+func (a *PaperAccount) finish(o *paperOrder, status OrderStatus) {
+	lockAsset := o.quote
+	if o.Side == OrderSideSell {
+		lockAsset = o.base
+	}
+
+	b := a.balance(lockAsset)
+	b.locked -= o.locked
+	b.free += o.locked
+	o.locked = 0
+
+	o.Status = status
+	o.Updated = a.time()
+}
@@ -0,0 +1,231 @@
+package binance
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPaperTrading(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v3/ticker/price" {
+			t.Errorf("paper request leaked: %s", r.URL.Path)
+		}
+
+		fmt.Fprint(w, `{"symbol":"BTCUSDT","price":"100.00000000"}`)
+	}))
+	defer server.Close()
+
+	account := NewPaperAccount(
+		PaperSymbols(SymbolInfo{Symbol: "BTCUSDT", BaseAsset: "BTC", QuoteAsset: "USDT"}),
+		PaperBalance("USDT", "1000"),
+		PaperCommission(0.001, 0.002),
+	)
+
+	client, _ := NewClient(BaseURL(server.URL), PaperTrading(account))
+
+	price, err := client.LatestPrice("BTCUSDT")
+	if err != nil || price != "100.00000000" {
+		t.Fatalf("public request failed: %v %s", err, price)
+	}
+
+	account.Tick("BTCUSDT", price, Time{})
+
+	// Resting limit buy, filled as maker when the price drops below.
+	buy, _ := LimitBuy("BTCUSDT", "2", "90")
+	err = client.SubmitOrder(buy)
+	if err != nil {
+		t.Fatalf("SubmitOrder failed: %s", err.Error())
+	}
+
+	if buy.Status != New {
+		t.Errorf("limit buy got status %s", buy.Status)
+	}
+
+	account.Tick("BTCUSDT", "95", Time{})
+	account.Tick("BTCUSDT", "89", Time{})
+
+	order, err := client.OrderStatus("BTCUSDT", buy.ClientOrderID, 0)
+	if err != nil || order.Status != Filled || order.CummulativeQuoteQuantity != "180.00000000" {
+		t.Errorf("limit buy not filled: %v %+v", err, order)
+	}
+
+	// Market sell, filled as taker right away.
+	sell, _ := MarketSell("BTCUSDT", "1", WithResponseType(OrderResponseFull))
+	err = client.SubmitOrder(sell)
+	if err != nil {
+		t.Fatalf("SubmitOrder failed: %s", err.Error())
+	}
+
+	if sell.Status != Filled || len(sell.Fills) != 1 || sell.Fills[0].Price != "89.00000000" || sell.Fills[0].Commission != "0.17800000" {
+		t.Errorf("market sell got %+v", sell)
+	}
+
+	// Stop loss below the market, triggered later.
+	stop, _ := StopLoss("BTCUSDT", OrderSideSell, "0.5", "80")
+	err = client.SubmitOrder(stop)
+	if err != nil {
+		t.Fatalf("SubmitOrder failed: %s", err.Error())
+	}
+
+	// The rest is locked by the stop, so this must fail.
+	tooMuch, _ := MarketSell("BTCUSDT", "1.5")
+	err = client.SubmitOrder(tooMuch)
+	if e, ok := err.(*APIError); !ok || e.Code != -2010 {
+		t.Errorf("oversell returned %v", err)
+	}
+
+	account.Tick("BTCUSDT", "79", Time{})
+
+	open, err := client.OpenOrders("BTCUSDT")
+	if err != nil || len(open) != 0 {
+		t.Errorf("orders still open: %v %+v", err, open)
+	}
+
+	info, err := client.AccountInfo()
+	if err != nil {
+		t.Fatalf("AccountInfo failed: %s", err.Error())
+	}
+
+	// 2 BTC bought at 90 with 0.1% commission, 1 sold at 89 and 0.5 at 79,
+	// both with 0.2% commission.
+	expected := "[{BTC 0.49800000 0.00000000} {USDT 948.24300000 0.00000000}]"
+	if fmt.Sprint(info.Balances) != expected {
+		t.Errorf("got balances %v, expected %s", info.Balances, expected)
+	}
+
+	trades, err := client.MyTrades("BTCUSDT")
+	if err != nil || len(trades) != 3 || !trades[0].IsMaker || trades[1].IsMaker {
+		t.Errorf("got trades %v %+v", err, trades)
+	}
+
+	_, err = client.CancelOrder("BTCUSDT", stop.ClientOrderID, 0)
+	if e, ok := err.(*APIError); !ok || e.Code != -2011 {
+		t.Errorf("canceling a filled order returned %v", err)
+	}
+
+	_, err = client.OpenOrderLists()
+	if e, ok := err.(*APIError); !ok || e.Code != -1020 {
+		t.Errorf("unsupported endpoint returned %v", err)
+	}
+}
+
+func TestPaperQuotes(t *testing.T) {
+	account := NewPaperAccount(
+		PaperSymbols(SymbolInfo{Symbol: "BTCUSDT", BaseAsset: "BTC", QuoteAsset: "USDT"}),
+		PaperBalance("USDT", "1000"),
+	)
+
+	client, _ := NewClient(BaseURL("http://localhost:1"), PaperTrading(account))
+
+	best := &BestPrice{}
+	best.Bid.Price, best.Ask.Price = "99", "101"
+	account.Quote("BTCUSDT", best, Time{})
+
+	// Market orders take the best ask or bid.
+	buy, _ := MarketBuy("BTCUSDT", "1", WithResponseType(OrderResponseFull))
+	sell, _ := MarketSell("BTCUSDT", "1", WithResponseType(OrderResponseFull))
+
+	for _, c := range []struct {
+		order    *Order
+		expected Value
+	}{{buy, "101.00000000"}, {sell, "99.00000000"}} {
+		err := client.SubmitOrder(c.order)
+		if err != nil || len(c.order.Fills) != 1 || c.order.Fills[0].Price != c.expected {
+			t.Errorf("market %s got %v %+v", c.order.Side, err, c.order)
+		}
+	}
+
+	// Resting limit buy, filled as maker when the ask drops to it.
+	limit, _ := LimitBuy("BTCUSDT", "2", "100")
+	err := client.SubmitOrder(limit)
+	if err != nil || limit.Status != New {
+		t.Fatalf("limit buy got %v %+v", err, limit)
+	}
+
+	best.Bid.Price, best.Ask.Price = "98", "100"
+	account.Quote("BTCUSDT", best, Time{})
+
+	order, err := client.OrderStatus("BTCUSDT", limit.ClientOrderID, 0)
+	if err != nil || order.Status != Filled || order.CummulativeQuoteQuantity != "200.00000000" {
+		t.Errorf("limit buy not filled: %v %+v", err, order)
+	}
+}
+
+func TestPaperTradingHTTPClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("paper request leaked: %s", r.URL.Path)
+	}))
+	defer server.Close()
+
+	account := NewPaperAccount(
+		PaperSymbols(SymbolInfo{Symbol: "BTCUSDT", BaseAsset: "BTC", QuoteAsset: "USDT"}),
+		PaperBalance("USDT", "1000"),
+	)
+	account.Tick("BTCUSDT", "100", Time{})
+
+	// The HTTP client is replaced after paper trading was enabled, both as
+	// a later option and later on.
+	client, _ := NewClient(BaseURL(server.URL), PaperTrading(account), HTTPClient(&http.Client{}))
+	client.SetOptions(HTTPClient(http.DefaultClient))
+
+	order, _ := MarketBuy("BTCUSDT", "1")
+	err := client.SubmitOrder(order)
+	if err != nil || order.Status != Filled {
+		t.Errorf("SubmitOrder returned %v %+v", err, order)
+	}
+
+	// Signed requests the account can't answer are refused.
+	_, err = client.OpenOrderLists()
+	if e, ok := err.(*APIError); !ok || e.Code != -1020 {
+		t.Errorf("unsupported endpoint returned %v", err)
+	}
+
+	_, err = client.WSAPIClient()
+	if err == nil {
+		t.Errorf("WSAPIClient available when paper trading")
+	}
+}
+
+func TestPaperMatching(t *testing.T) {
+	account := NewPaperAccount(
+		PaperSymbols(SymbolInfo{Symbol: "BTCUSDT", BaseAsset: "BTC", QuoteAsset: "USDT"}),
+		PaperBalance("USDT", "1000"),
+		PaperBalance("BTC", "1"),
+	)
+
+	client, _ := NewClient(BaseURL("http://localhost:1"), PaperTrading(account))
+
+	// IOC orders can't wait for a price.
+	ioc, _ := LimitBuy("BTCUSDT", "1", "100", WithTimeInForce(IOC))
+	err := client.SubmitOrder(ioc)
+	if e, ok := err.(*APIError); !ok || e.Code != -2010 {
+		t.Errorf("IOC without a price returned %v", err)
+	}
+
+	account.Tick("BTCUSDT", "100", Time{})
+
+	// The bid is below the stop, but only trades trigger it.
+	stop, _ := StopLoss("BTCUSDT", OrderSideSell, "1", "90")
+	err = client.SubmitOrder(stop)
+	if err != nil {
+		t.Fatalf("SubmitOrder failed: %s", err.Error())
+	}
+
+	best := &BestPrice{}
+	best.Bid.Price, best.Ask.Price = "85", "101"
+	account.Quote("BTCUSDT", best, Time{})
+
+	order, err := client.OrderStatus("BTCUSDT", stop.ClientOrderID, 0)
+	if err != nil || order.Status != New {
+		t.Errorf("stop triggered by quote: %v %+v", err, order)
+	}
+
+	account.Tick("BTCUSDT", "89", Time{})
+
+	order, err = client.OrderStatus("BTCUSDT", stop.ClientOrderID, 0)
+	if err != nil || order.Status != Filled {
+		t.Errorf("stop not triggered by trade: %v %+v", err, order)
+	}
+}
@@ -0,0 +1,44 @@
+package binance
+
+import (
+	"encoding/json"
+)
+
+// PartialDepthEvent is pushed by the partial book depth streams. It carries
+// the top bids and asks of the order book.
+type PartialDepthEvent struct {
+	LastUpdateID int64
+	Bids         []OrderBookPoint
+	Asks         []OrderBookPoint
+}
+
+// UnmarshalJSON implements json.Unmarshaler. The levels are sent in the same
+// format as the REST order book.
+func (e *PartialDepthEvent) UnmarshalJSON(data []byte) error {
+	proxy := &orderBookProxy{}
+
+	err := json.Unmarshal(data, proxy)
+	if err != nil {
+		return err
+	}
+
+	book, err := proxy.real()
+	if err != nil {
+		return err
+	}
+
+	e.LastUpdateID = book.LastUpdateID
+	e.Bids = book.Bids
+	e.Asks = book.Asks
+
+	return nil
+}
+
+// OrderBook returns the event as an OrderBook.
+func (e *PartialDepthEvent) OrderBook() *OrderBook {
+	return &OrderBook{
+		LastUpdateID: e.LastUpdateID,
+		Bids:         e.Bids,
+		Asks:         e.Asks,
+	}
+}
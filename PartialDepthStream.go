@@ -0,0 +1,69 @@
+package binance
+
+import (
+	"fmt"
+	"time"
+
+	"golang.org/x/net/websocket"
+)
+
+// PartialDepthStream represents a stream from the partial book depth
+// endpoint.
+type PartialDepthStream struct {
+	*websocket.Conn
+}
+
+// Read a book snapshot from the stream. This will block until a snapshot is
+// ready.
+func (s *PartialDepthStream) Read() (*PartialDepthEvent, error) {
+	event := &PartialDepthEvent{}
+
+	err := websocket.JSON.Receive(s.Conn, event)
+	if err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
+
+// PartialDepthStream will open a websocket stream that will stream the top
+// levels bids and asks for symbol. levels must be 5, 10 or 20. speed is the
+// update speed and can be 100ms or 1000ms, zero will use the Binance default
+// of 1000ms. You should call Close() when done.
+func (c *Client) PartialDepthStream(symbol Symbol, levels int, speed time.Duration) (*PartialDepthStream, error) {
+	var typ StreamType
+
+	switch levels {
+	case 5:
+		typ = StreamTypePartialDepth5
+	case 10:
+		typ = StreamTypePartialDepth10
+	case 20:
+		typ = StreamTypePartialDepth20
+	default:
+		return nil, fmt.Errorf("%d is not a valid number of levels", levels)
+	}
+
+	id := NewStreamID(symbol, typ)
+	if speed != 0 {
+		var err error
+
+		id, err = id.WithUpdateSpeed(speed)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	URL := fmt.Sprintf("%s/ws/%s", c.streamBaseURL, id)
+
+	conn, err := websocket.Dial(URL, "", "http://localhost/")
+	if err != nil {
+		return nil, err
+	}
+
+	stream := &PartialDepthStream{
+		Conn: conn,
+	}
+
+	return stream, nil
+}
@@ -0,0 +1,48 @@
+package binance
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// PositionSide is the side of a futures position. In one-way mode all
+// positions are PositionSideBoth.
+type PositionSide string
+
+// The different position sides.
+const (
+	PositionSideBoth  PositionSide = "BOTH"
+	PositionSideLong  PositionSide = "LONG"
+	PositionSideShort PositionSide = "SHORT"
+
+	zeroPositionSide PositionSide = ""
+)
+
+// UnmarshalJSON implements json.Unmarshaler while making sure only enums
+// that we know about end up in a PositionSide variable.
+func (p *PositionSide) UnmarshalJSON(data []byte) error {
+	str := ""
+	err := json.Unmarshal(data, &str)
+	if err != nil {
+		return err
+	}
+
+	side := PositionSide(str)
+
+	switch side {
+	case
+		PositionSideBoth,
+		PositionSideLong,
+		PositionSideShort:
+		*p = side
+	default:
+		return fmt.Errorf("%s is not a valid position side", str)
+	}
+
+	return nil
+}
+
+// String implement Stringer.
+func (p PositionSide) String() string {
+	return string(p)
+}
@@ -2,7 +2,12 @@ package binance
 
 // RateLimit describes a rate limit at the exchange.
 type RateLimit struct {
-	Type     string `json:"rateLimitType"` // FIXME: type
-	Interval string `json:"interval"`      // FIXME: Type
-	Limit    int64  `json:"limit"`
+	Type        string `json:"rateLimitType"` // FIXME: type
+	Interval    string `json:"interval"`      // FIXME: Type
+	IntervalNum int    `json:"intervalNum"`
+	Limit       int64  `json:"limit"`
+
+	// Count is the current usage. This is only set for rate limits
+	// returned by the websocket API.
+	Count int64 `json:"count"`
 }
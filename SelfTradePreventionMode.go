@@ -0,0 +1,52 @@
+package binance
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// SelfTradePreventionMode decides what happens when an order would match
+// another order from the same account or trade group.
+type SelfTradePreventionMode string
+
+// The different self-trade prevention modes.
+const (
+	SelfTradePreventionNone        SelfTradePreventionMode = "NONE"
+	SelfTradePreventionExpireTaker SelfTradePreventionMode = "EXPIRE_TAKER"
+	SelfTradePreventionExpireMaker SelfTradePreventionMode = "EXPIRE_MAKER"
+	SelfTradePreventionExpireBoth  SelfTradePreventionMode = "EXPIRE_BOTH"
+	SelfTradePreventionDecrement   SelfTradePreventionMode = "DECREMENT"
+
+	zeroSelfTradePreventionMode SelfTradePreventionMode = ""
+)
+
+// UnmarshalJSON implements json.Unmarshaler while making sure only enums
+// that we know about end up in a SelfTradePreventionMode variable.
+func (s *SelfTradePreventionMode) UnmarshalJSON(data []byte) error {
+	str := ""
+	err := json.Unmarshal(data, &str)
+	if err != nil {
+		return err
+	}
+
+	mode := SelfTradePreventionMode(str)
+
+	switch mode {
+	case
+		SelfTradePreventionNone,
+		SelfTradePreventionExpireTaker,
+		SelfTradePreventionExpireMaker,
+		SelfTradePreventionExpireBoth,
+		SelfTradePreventionDecrement:
+		*s = mode
+	default:
+		return fmt.Errorf("%s is not a valid self-trade prevention mode", str)
+	}
+
+	return nil
+}
+
+// String implement Stringer.
+func (s SelfTradePreventionMode) String() string {
+	return string(s)
+}
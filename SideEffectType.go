@@ -0,0 +1,50 @@
+package binance
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// SideEffectType decides whether a margin order borrows or repays
+// automatically.
+type SideEffectType string
+
+// The different side effects of margin orders.
+const (
+	SideEffectNone            SideEffectType = "NO_SIDE_EFFECT"
+	SideEffectMarginBuy       SideEffectType = "MARGIN_BUY"
+	SideEffectAutoRepay       SideEffectType = "AUTO_REPAY"
+	SideEffectAutoBorrowRepay SideEffectType = "AUTO_BORROW_REPAY"
+
+	zeroSideEffectType SideEffectType = ""
+)
+
+// UnmarshalJSON implements json.Unmarshaler while making sure only enums
+// that we know about end up in a SideEffectType variable.
+func (s *SideEffectType) UnmarshalJSON(data []byte) error {
+	str := ""
+	err := json.Unmarshal(data, &str)
+	if err != nil {
+		return err
+	}
+
+	typ := SideEffectType(str)
+
+	switch typ {
+	case
+		SideEffectNone,
+		SideEffectMarginBuy,
+		SideEffectAutoRepay,
+		SideEffectAutoBorrowRepay:
+		*s = typ
+	default:
+		return fmt.Errorf("%s is not a valid side effect type", str)
+	}
+
+	return nil
+}
+
+// String implement Stringer.
+func (s SideEffectType) String() string {
+	return string(s)
+}
@@ -1,16 +1,39 @@
 package binance
 
 import (
+	"fmt"
 	"strings"
+	"time"
 )
 
 // StreamID identifies a stream from Binance.
 type StreamID string
 
+// The all market streams are not bound to a symbol, so they can't be
+// created by NewStreamID(). Each message from these streams will carry a
+// slice of tickers for all symbols that changed.
+const (
+	StreamAllMarketTickers     StreamID = "!ticker@arr"
+	StreamAllMarketMiniTickers StreamID = "!miniTicker@arr"
+	StreamAllMarketTickers1h   StreamID = "!ticker_1h@arr"
+	StreamAllMarketTickers4h   StreamID = "!ticker_4h@arr"
+	StreamAllMarketTickers1d   StreamID = "!ticker_1d@arr"
+)
+
+// split will split s in symbol, type and an optional update speed suffix.
+func (s StreamID) split() []string {
+	parts := strings.Split(string(s), "@")
+	if len(parts) != 2 && len(parts) != 3 {
+		return nil
+	}
+
+	return parts
+}
+
 // Type returns the type of a stream.
 func (s StreamID) Type() StreamType {
-	parts := strings.Split(string(s), "@")
-	if len(parts) != 2 {
+	parts := s.split()
+	if parts == nil {
 		return ""
 	}
 
@@ -19,14 +42,52 @@ func (s StreamID) Type() StreamType {
 
 // Symbol returns the symbol of a stream.
 func (s StreamID) Symbol() Symbol {
-	parts := strings.Split(string(s), "@")
-	if len(parts) != 2 {
+	parts := s.split()
+	if parts == nil {
 		return ""
 	}
 
 	return Symbol(parts[0])
 }
 
+// UpdateSpeed returns the update speed requested for s, or zero if the
+// stream will use the default speed.
+func (s StreamID) UpdateSpeed() time.Duration {
+	parts := s.split()
+	if len(parts) != 3 {
+		return 0
+	}
+
+	speed, err := time.ParseDuration(parts[2])
+	if err != nil {
+		return 0
+	}
+
+	return speed
+}
+
+// WithUpdateSpeed returns a stream ID requesting updates every speed. Only
+// the depth streams support this, and only 100ms and 1000ms.
+func (s StreamID) WithUpdateSpeed(speed time.Duration) (StreamID, error) {
+	if speed != 100*time.Millisecond && speed != time.Second {
+		return "", fmt.Errorf("%s is not a valid update speed", speed)
+	}
+
+	return StreamID(fmt.Sprintf("%s@%dms", s, speed/time.Millisecond)), nil
+}
+
+// iface returns the type used to represent events from s - or nil if it's
+// unknown or not implemented. The all market streams share a stream type,
+// so we have to look at the whole ID to tell them apart.
+func (s StreamID) iface() interface{} {
+	if s.Type() == StreamTypeAllMarkedsMarketTickers &&
+		strings.HasPrefix(string(s.Symbol()), "!miniTicker") {
+		return new([]MiniTickerEvent)
+	}
+
+	return s.Type().iface()
+}
+
 // NewStreamID will return a new StreamID consisting of a symbol and a stream
 // type.
 func NewStreamID(symbol Symbol, typ StreamType) StreamID {
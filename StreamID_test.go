@@ -0,0 +1,54 @@
+package binance
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStreamIDSplit(t *testing.T) {
+	cases := []struct {
+		id     StreamID
+		symbol Symbol
+		typ    StreamType
+		speed  time.Duration
+	}{
+		{"bnbbtc@trade", "bnbbtc", StreamTypeTrade, 0},
+		{"bnbbtc@depth5@100ms", "bnbbtc", StreamTypePartialDepth5, 100 * time.Millisecond},
+		{NewStreamID("BNBBTC", StreamTypePartialDepth10) + "@1000ms", "bnbbtc", StreamTypePartialDepth10, time.Second},
+		{StreamAllMarketMiniTickers, "!miniTicker", StreamTypeAllMarkedsMarketTickers, 0},
+		{"bnbbtc", "", "", 0},
+	}
+
+	for _, c := range cases {
+		if c.id.Symbol() != c.symbol {
+			t.Errorf("%s: got symbol '%s', expected '%s'", c.id, c.id.Symbol(), c.symbol)
+		}
+
+		if c.id.Type() != c.typ {
+			t.Errorf("%s: got type '%s', expected '%s'", c.id, c.id.Type(), c.typ)
+		}
+
+		if c.id.UpdateSpeed() != c.speed {
+			t.Errorf("%s: got speed %s, expected %s", c.id, c.id.UpdateSpeed(), c.speed)
+		}
+	}
+}
+
+func TestStreamIDWithUpdateSpeed(t *testing.T) {
+	cases := []struct {
+		speed    time.Duration
+		expected StreamID
+	}{
+		{100 * time.Millisecond, "bnbbtc@depth5@100ms"},
+		{time.Second, "bnbbtc@depth5@1000ms"},
+		{500 * time.Millisecond, ""},
+		{0, ""},
+	}
+
+	for _, c := range cases {
+		id, err := NewStreamID("BNBBTC", StreamTypePartialDepth5).WithUpdateSpeed(c.speed)
+		if id != c.expected || (c.expected == "") != (err != nil) {
+			t.Errorf("%s: got '%s' %v, expected '%s'", c.speed, id, err, c.expected)
+		}
+	}
+}
@@ -7,6 +7,7 @@ type StreamType string
 const (
 	StreamTypeAggregatedTrade         StreamType = "aggTrade"
 	StreamTypeTrade                   StreamType = "trade"
+	StreamTypeKLine1s                 StreamType = "kline_1s"
 	StreamTypeKLine1m                 StreamType = "kline_1m"
 	StreamTypeKLine3m                 StreamType = "kline_3m"
 	StreamTypeKLine5m                 StreamType = "kline_5m"
@@ -23,11 +24,16 @@ const (
 	StreamTypeKLine1w                 StreamType = "kline_1w"
 	StreamTypeKLine1M                 StreamType = "kline_1M"
 	StreamTypeTicker                  StreamType = "ticker"
+	StreamTypeTicker1h                StreamType = "ticker_1h"
+	StreamTypeTicker4h                StreamType = "ticker_4h"
+	StreamTypeTicker1d                StreamType = "ticker_1d"
+	StreamTypeMiniTicker              StreamType = "miniTicker"
 	StreamTypeAllMarkedsMarketTickers StreamType = "arr"
 	StreamTypePartialDepth5           StreamType = "depth5"
 	StreamTypePartialDepth10          StreamType = "depth10"
 	StreamTypePartialDepth20          StreamType = "depth20"
 	StreamTypeDepth                   StreamType = "depth"
+	StreamTypeBookTicker              StreamType = "bookTicker"
 )
 
 // iface returns the type used to represent t - or nil if it's unknown or not
@@ -40,17 +46,28 @@ func (t StreamType) iface() interface{} {
 	case StreamTypeTrade:
 		return new(Trade)
 
-	case StreamTypeKLine1m, StreamTypeKLine3m, StreamTypeKLine5m,
-		StreamTypeKLine15m, StreamTypeKLine30m, StreamTypeKLine1h,
-		StreamTypeKLine2h, StreamTypeKLine4h, StreamTypeKLine6h,
-		StreamTypeKLine8h, StreamTypeKLine12h, StreamTypeKLine1d,
-		StreamTypeKLine3d, StreamTypeKLine1w, StreamTypeKLine1M:
+	case StreamTypeKLine1s, StreamTypeKLine1m, StreamTypeKLine3m,
+		StreamTypeKLine5m, StreamTypeKLine15m, StreamTypeKLine30m,
+		StreamTypeKLine1h, StreamTypeKLine2h, StreamTypeKLine4h,
+		StreamTypeKLine6h, StreamTypeKLine8h, StreamTypeKLine12h,
+		StreamTypeKLine1d, StreamTypeKLine3d, StreamTypeKLine1w,
+		StreamTypeKLine1M:
+		return new(KlineEvent)
 
-	case StreamTypeTicker:
+	case StreamTypeTicker, StreamTypeTicker1h, StreamTypeTicker4h, StreamTypeTicker1d:
+		return new(TickerEvent)
+
+	case StreamTypeMiniTicker:
+		return new(MiniTickerEvent)
 
 	case StreamTypeAllMarkedsMarketTickers:
+		return new([]TickerEvent)
 
 	case StreamTypePartialDepth5, StreamTypePartialDepth10, StreamTypePartialDepth20:
+		return new(PartialDepthEvent)
+
+	case StreamTypeBookTicker:
+		return new(BookTickerEvent)
 
 	case StreamTypeDepth:
 	}
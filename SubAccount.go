@@ -0,0 +1,119 @@
+package binance
+
+import (
+	"encoding/json"
+	"net/url"
+)
+
+// SubAccount describes a sub-account of the master account.
+type SubAccount struct {
+	Email                       string `json:"email"`
+	IsFreeze                    bool   `json:"isFreeze"`
+	CreateTime                  Time   `json:"createTime"`
+	IsManagedSubAccount         bool   `json:"isManagedSubAccount"`
+	IsAssetManagementSubAccount bool   `json:"isAssetManagementSubAccount"`
+}
+
+// SubAccounts lists the sub-accounts of the master account, 200 per page.
+// page starts at 1.
+func (c *Client) SubAccounts(page int) ([]SubAccount, error) {
+	var result struct {
+		SubAccounts []SubAccount `json:"subAccounts"`
+	}
+
+	err := c.signedCall(&result, "GET", "/sapi/v1/sub-account/list",
+		param("page", page),
+		param("limit", 200),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.SubAccounts, nil
+}
+
+// SubAccountBalances returns the spot balances of the sub-account with
+// email.
+func (c *Client) SubAccountBalances(email string) ([]Balance, error) {
+	// This endpoint returns numbers instead of strings.
+	var result struct {
+		Balances []struct {
+			Asset  string      `json:"asset"`
+			Free   json.Number `json:"free"`
+			Locked json.Number `json:"locked"`
+		} `json:"balances"`
+	}
+
+	err := c.signedCall(&result, "GET", "/sapi/v3/sub-account/assets", param("email", email))
+	if err != nil {
+		return nil, err
+	}
+
+	balances := make([]Balance, len(result.Balances))
+	for i, b := range result.Balances {
+		balances[i] = Balance{
+			Asset:  b.Asset,
+			Free:   Value(b.Free.String()),
+			Locked: Value(b.Locked.String()),
+		}
+	}
+
+	return balances, nil
+}
+
+// SubAccountTransfer describes a transfer between sub-accounts, or between
+// a sub-account and the master account. Leave an email empty for the master
+// account.
+type SubAccountTransfer struct {
+	FromEmail   string
+	ToEmail     string
+	FromAccount TransferAccount
+	ToAccount   TransferAccount
+	Asset       string
+	Amount      Value
+
+	// Symbol is required for isolated margin.
+	Symbol Symbol
+
+	// ClientTransferID identifies the transfer. Binance will reject a
+	// second transfer with the same ID.
+	ClientTransferID string
+}
+
+// TransferSubAccount will make the transfer t from the master account, and
+// return the ID of the transfer.
+func (c *Client) TransferSubAccount(t *SubAccountTransfer) (int64, error) {
+	params := []func(url.Values){
+		param("fromAccountType", t.FromAccount),
+		param("toAccountType", t.ToAccount),
+		param("asset", t.Asset),
+		param("amount", t.Amount),
+	}
+
+	if t.FromEmail != "" {
+		params = append(params, param("fromEmail", t.FromEmail))
+	}
+
+	if t.ToEmail != "" {
+		params = append(params, param("toEmail", t.ToEmail))
+	}
+
+	if t.Symbol != zeroSymbol {
+		params = append(params, param("symbol", t.Symbol.UpperCase()))
+	}
+
+	if t.ClientTransferID != "" {
+		params = append(params, param("clientTranId", t.ClientTransferID))
+	}
+
+	var result struct {
+		ID int64 `json:"tranId"`
+	}
+
+	err := c.signedCall(&result, "POST", "/sapi/v1/sub-account/universalTransfer", params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.ID, nil
+}
@@ -1,5 +1,9 @@
 package binance
 
+import (
+	"fmt"
+)
+
 // SymbolInfo describes various details about a trading symbol.
 type SymbolInfo struct {
 	Symbol              Symbol      `json:"symbol"`
@@ -10,5 +14,21 @@ type SymbolInfo struct {
 	QuoteAssetPrecision int         `json:"quotePrecision"`
 	OrderTypes          []OrderType `json:"orderTypes"`
 	AllowIceberg        bool        `json:"icebergAllowed"`
-	//Filters             []Filter    `json:"filters"` // FIXME: Implement
+	Filters             []Filter    `json:"filters"`
+}
+
+// SymbolInfo returns the trading rules for symbol.
+func (c *Client) SymbolInfo(symbol Symbol) (*SymbolInfo, error) {
+	info := &ExchangeInfo{}
+
+	err := c.publicGet(info, "/api/v3/exchangeInfo", param("symbol", symbol.UpperCase()))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(info.Symbols) != 1 {
+		return nil, fmt.Errorf("got %d symbols for %s", len(info.Symbols), symbol)
+	}
+
+	return &info.Symbols[0], nil
 }
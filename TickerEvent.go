@@ -0,0 +1,83 @@
+package binance
+
+// TickerEvent is pushed by the individual symbol ticker streams. The 24 hour
+// ticker and the rolling window tickers share this type, the rolling window
+// tickers will not carry the last quantity nor the best bid and ask.
+type TickerEvent struct {
+	EventType            string `json:"e"`
+	EventTime            Time   `json:"E"`
+	Symbol               Symbol `json:"s"`
+	PriceChange          Value  `json:"p"`
+	PriceChangePercent   Value  `json:"P"`
+	WeightedAveragePrice Value  `json:"w"`
+	PreviousClosePrice   Value  `json:"x"`
+	LastPrice            Value  `json:"c"`
+	LastQuantity         Value  `json:"Q"`
+	BidPrice             Value  `json:"b"`
+	BidQuantity          Value  `json:"B"`
+	AskPrice             Value  `json:"a"`
+	AskQuantity          Value  `json:"A"`
+	OpenPrice            Value  `json:"o"`
+	HighPrice            Value  `json:"h"`
+	LowPrice             Value  `json:"l"`
+	Volume               Value  `json:"v"`
+	QuoteVolume          Value  `json:"q"`
+	OpenTime             Time   `json:"O"`
+	CloseTime            Time   `json:"C"`
+	FirstTradeID         int64  `json:"F"`
+	LastTradeID          int64  `json:"L"`
+	NumberOfTrades       int    `json:"n"`
+}
+
+// ChangeStatistics returns the ticker as ChangeStatistics as known from the
+// REST API.
+func (e *TickerEvent) ChangeStatistics() ChangeStatistics {
+	return ChangeStatistics{
+		Symbol:                e.Symbol,
+		PriceChange:           e.PriceChange,
+		PriceChangePercent:    e.PriceChangePercent,
+		WeightedAveragegPrice: e.WeightedAveragePrice,
+		PreviousClosePrice:    e.PreviousClosePrice,
+		LastPrice:             e.LastPrice,
+		LastQuantity:          e.LastQuantity,
+		BidPrice:              e.BidPrice,
+		AskPrice:              e.AskPrice,
+		PpenPrice:             e.OpenPrice,
+		HighPrice:             e.HighPrice,
+		LowPrice:              e.LowPrice,
+		Volume:                e.Volume,
+		QuoteVolume:           e.QuoteVolume,
+		OpenTime:              e.OpenTime,
+		CloseTime:             e.CloseTime,
+		FirstTradeID:          e.FirstTradeID,
+		LastTradeID:           e.LastTradeID,
+		NumberOfTrades:        e.NumberOfTrades,
+	}
+}
+
+// MiniTickerEvent is pushed by the mini ticker streams.
+type MiniTickerEvent struct {
+	EventType   string `json:"e"`
+	EventTime   Time   `json:"E"`
+	Symbol      Symbol `json:"s"`
+	ClosePrice  Value  `json:"c"`
+	OpenPrice   Value  `json:"o"`
+	HighPrice   Value  `json:"h"`
+	LowPrice    Value  `json:"l"`
+	Volume      Value  `json:"v"`
+	QuoteVolume Value  `json:"q"`
+}
+
+// ChangeStatistics returns the mini ticker as ChangeStatistics. Only the
+// fields carried by the mini ticker will be set.
+func (e *MiniTickerEvent) ChangeStatistics() ChangeStatistics {
+	return ChangeStatistics{
+		Symbol:      e.Symbol,
+		LastPrice:   e.ClosePrice,
+		PpenPrice:   e.OpenPrice,
+		HighPrice:   e.HighPrice,
+		LowPrice:    e.LowPrice,
+		Volume:      e.Volume,
+		QuoteVolume: e.QuoteVolume,
+	}
+}
@@ -0,0 +1,85 @@
+package binance
+
+import (
+	"fmt"
+
+	"golang.org/x/net/websocket"
+)
+
+// TickerStream represents a stream from one of the individual symbol ticker
+// endpoints.
+type TickerStream struct {
+	*websocket.Conn
+}
+
+// Read a ticker from the stream. This will block until a ticker is ready.
+func (s *TickerStream) Read() (*TickerEvent, error) {
+	event := &TickerEvent{}
+
+	err := websocket.JSON.Receive(s.Conn, event)
+	if err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
+
+// TickerStream will open a websocket stream that will stream ticker
+// statistics for symbol. typ must be StreamTypeTicker for 24 hour statistics
+// or one of the rolling window types like StreamTypeTicker1h. Mini tickers
+// are streamed by MiniTickerStream(). You should call Close() when done.
+func (c *Client) TickerStream(symbol Symbol, typ StreamType) (*TickerStream, error) {
+	switch typ {
+	case StreamTypeTicker, StreamTypeTicker1h, StreamTypeTicker4h, StreamTypeTicker1d:
+	default:
+		return nil, fmt.Errorf("%s is not a ticker stream type", typ)
+	}
+
+	URL := fmt.Sprintf("%s/ws/%s", c.streamBaseURL, NewStreamID(symbol, typ))
+
+	conn, err := websocket.Dial(URL, "", "http://localhost/")
+	if err != nil {
+		return nil, err
+	}
+
+	stream := &TickerStream{
+		Conn: conn,
+	}
+
+	return stream, nil
+}
+
+// MiniTickerStream represents a stream from the mini ticker endpoint.
+type MiniTickerStream struct {
+	*websocket.Conn
+}
+
+// Read a mini ticker from the stream. This will block until a ticker is
+// ready.
+func (s *MiniTickerStream) Read() (*MiniTickerEvent, error) {
+	event := &MiniTickerEvent{}
+
+	err := websocket.JSON.Receive(s.Conn, event)
+	if err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
+
+// MiniTickerStream will open a websocket stream that will stream mini
+// tickers for symbol. You should call Close() when done.
+func (c *Client) MiniTickerStream(symbol Symbol) (*MiniTickerStream, error) {
+	URL := fmt.Sprintf("%s/ws/%s", c.streamBaseURL, NewStreamID(symbol, StreamTypeMiniTicker))
+
+	conn, err := websocket.Dial(URL, "", "http://localhost/")
+	if err != nil {
+		return nil, err
+	}
+
+	stream := &MiniTickerStream{
+		Conn: conn,
+	}
+
+	return stream, nil
+}
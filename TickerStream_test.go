@@ -0,0 +1,37 @@
+package binance
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/websocket"
+)
+
+func TestTickerStreamType(t *testing.T) {
+	server := httptest.NewServer(websocket.Handler(func(conn *websocket.Conn) {}))
+	defer server.Close()
+
+	client, _ := NewClient(StreamBaseURL("ws" + strings.TrimPrefix(server.URL, "http")))
+
+	cases := []struct {
+		typ   StreamType
+		valid bool
+	}{
+		{StreamTypeTicker, true},
+		{StreamTypeTicker4h, true},
+		{StreamTypeMiniTicker, false},
+		{StreamTypeBookTicker, false},
+	}
+
+	for _, c := range cases {
+		stream, err := client.TickerStream("BNBBTC", c.typ)
+		if (err == nil) != c.valid {
+			t.Errorf("%s: got %v", c.typ, err)
+		}
+
+		if stream != nil {
+			stream.Close()
+		}
+	}
+}
@@ -1,10 +1,15 @@
 package binance
 
 import (
+	"encoding/json"
 	"strconv"
 	"time"
 )
 
+// walletTimeLayout is the layout of times returned by some of the wallet
+// endpoints.
+const walletTimeLayout = "2006-01-02 15:04:05"
+
 // Time is a type that matches Binance's millisecond precision timestamps. It's
 // embedding time.Time so all the usual time.Time methods should work as
 // expected.
@@ -17,6 +22,20 @@ type Time struct {
 func (t *Time) UnmarshalJSON(data []byte) error {
 	i, err := strconv.ParseInt(string(data), 10, 64)
 	if err != nil {
+		// The wallet endpoints use a formatted UTC time, sometimes empty.
+		var s string
+		if json.Unmarshal(data, &s) == nil {
+			if s == "" {
+				t.Time = time.Time{}
+				return nil
+			}
+
+			if parsed, err := time.Parse(walletTimeLayout, s); err == nil {
+				t.Time = parsed
+				return nil
+			}
+		}
+
 		// Try de decode as a regular timestamp. Sigh.
 		return t.Time.UnmarshalJSON(data)
 	}
@@ -15,6 +15,10 @@ const (
 	ImmediateOrCancel TimeInForce = "IOC"
 	FillOrKill        TimeInForce = "FOK"
 
+	// GoodTillCrossing and GoodTillDate are only supported for futures.
+	GoodTillCrossing TimeInForce = "GTX"
+	GoodTillDate     TimeInForce = "GTD"
+
 	GTC = GoodTillCancelled
 	IOC = ImmediateOrCancel
 	FOK = FillOrKill
@@ -34,7 +38,7 @@ func (t *TimeInForce) UnmarshalJSON(data []byte) error {
 	status := TimeInForce(s)
 
 	switch status {
-	case GTC, IOC, FOK:
+	case GTC, IOC, FOK, GoodTillCrossing, GoodTillDate:
 		*t = status
 	default:
 		return fmt.Errorf("%s is not a valid TimeInForce", s)
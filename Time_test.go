@@ -35,3 +35,26 @@ func TestTimeJSON(t *testing.T) {
 		}
 	}
 }
+
+func TestTimeWalletJSON(t *testing.T) {
+	cases := []struct {
+		data     string
+		expected int64
+	}{
+		{`"2019-10-12 11:12:02"`, 1570878722000},
+		{`""`, 0},
+	}
+
+	for _, c := range cases {
+		tim := Time{}
+		err := json.Unmarshal([]byte(c.data), &tim)
+		if err != nil {
+			t.Errorf("Failed to unmarshal %s: %s", c.data, err.Error())
+		}
+
+		ms := tim.UnixNano() / int64(time.Millisecond)
+		if c.expected == 0 && !tim.IsZero() || c.expected != 0 && ms != c.expected {
+			t.Errorf("%s decoded as %s", c.data, tim.Time)
+		}
+	}
+}
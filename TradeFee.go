@@ -0,0 +1,31 @@
+package binance
+
+import (
+	"net/url"
+)
+
+// TradeFee is the commission rates of a symbol as fractions, so 0.001 is
+// 0.1%.
+type TradeFee struct {
+	Symbol          Symbol `json:"symbol"`
+	MakerCommission Value  `json:"makerCommission"`
+	TakerCommission Value  `json:"takerCommission"`
+}
+
+// TradeFees returns the commission rates of symbol, or all symbols if symbol
+// is empty.
+func (c *Client) TradeFees(symbol Symbol) ([]TradeFee, error) {
+	params := []func(url.Values){}
+
+	if symbol != zeroSymbol {
+		params = append(params, param("symbol", symbol.UpperCase()))
+	}
+
+	var fees []TradeFee
+	err := c.signedCall(&fees, "GET", "/sapi/v1/asset/tradeFee", params...)
+	if err != nil {
+		return nil, err
+	}
+
+	return fees, nil
+}
@@ -2,10 +2,13 @@ package binance
 
 // TradeOrder is a trade order in the Binance system.
 type TradeOrder struct {
+	Symbol          Symbol `json:"symbol"`
 	ID              int64  `json:"id"`
 	OrderID         int64  `json:"orderId"`
+	OrderListID     int64  `json:"orderListId"`
 	Price           Value  `json:"price"`
 	Quantity        Value  `json:"qty"`
+	QuoteQuantity   Value  `json:"quoteQty"`
 	Commission      Value  `json:"commission"`
 	CommissionAsset string `json:"commissionAsset"` // FIXME: type
 	TimeStamp       Time   `json:"time"`
@@ -15,7 +18,9 @@ type TradeOrder struct {
 }
 
 // MyTrades return trades for a specific symbol. You can refine the query with
-// Limit() & FromID().
+// Limit(), FromID(), OrderID(), StartTime() and EndTime(). StartTime() and
+// EndTime() can't be more than 24 hours apart, use TradeHistory() for
+// walking longer periods.
 // Note: recvWindow parameter not supported (yet).
 func (c *Client) MyTrades(symbol Symbol, options ...QueryFunc) ([]TradeOrder, error) {
 	var orders []TradeOrder
@@ -30,3 +35,52 @@ func (c *Client) MyTrades(symbol Symbol, options ...QueryFunc) ([]TradeOrder, er
 
 	return orders, nil
 }
+
+// TradeIterator walks all trades for a symbol in a period. It's used like
+// OrderListIterator.
+type TradeIterator struct {
+	walker *historyWalker
+	trade  TradeOrder
+}
+
+// tradePage is a page of trades.
+type tradePage []TradeOrder
+
+func (p tradePage) Len() int        { return len(p) }
+func (p tradePage) ID(i int) int64  { return p[i].ID }
+func (p tradePage) Time(i int) Time { return p[i].TimeStamp }
+
+// TradeHistory returns an iterator walking all trades for symbol from start
+// until end.
+func (c *Client) TradeHistory(symbol Symbol, start Time, end Time) *TradeIterator {
+	fetch := func(options ...QueryFunc) (historyPage, error) {
+		trades, err := c.MyTrades(symbol, options...)
+
+		return tradePage(trades), err
+	}
+
+	return &TradeIterator{
+		walker: newHistoryWalker(start, end, FromID, fetch),
+	}
+}
+
+// Next advances the iterator to the next trade. It returns false when there
+// are no more trades or an error occurred.
+func (it *TradeIterator) Next() bool {
+	page, i, ok := it.walker.advance()
+	if ok {
+		it.trade = page.(tradePage)[i]
+	}
+
+	return ok
+}
+
+// Trade returns the current trade.
+func (it *TradeIterator) Trade() TradeOrder {
+	return it.trade
+}
+
+// Err returns the error that stopped the iterator, if any.
+func (it *TradeIterator) Err() error {
+	return it.walker.err
+}
@@ -0,0 +1,82 @@
+package binance
+
+import (
+	"net/url"
+)
+
+// Transfer describes a transfer between the wallets of the account.
+type Transfer struct {
+	ID        int64          `json:"tranId"`
+	Type      TransferType   `json:"type"`
+	Asset     string         `json:"asset"` // FIXME: type
+	Amount    Value          `json:"amount"`
+	Status    TransferStatus `json:"status"`
+	Timestamp Time           `json:"timestamp"`
+}
+
+// UniversalTransfer will transfer amount of asset between the wallets of the
+// account, and return the ID of the transfer. For transfers to or from
+// isolated margin, the symbols must be given with TransferSymbols().
+func (c *Client) UniversalTransfer(typ TransferType, asset string, amount Value, options ...TransferOption) (int64, error) {
+	params := []func(url.Values){
+		param("type", typ),
+		param("asset", asset),
+		param("amount", amount),
+	}
+
+	for _, option := range options {
+		params = append(params, option)
+	}
+
+	var result struct {
+		ID int64 `json:"tranId"`
+	}
+
+	err := c.signedCall(&result, "POST", "/sapi/v1/asset/transfer", params...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.ID, nil
+}
+
+// TransferOption is used for setting optional parameters of a transfer.
+type TransferOption func(url.Values)
+
+// TransferSymbols sets the isolated margin symbols to transfer from and to.
+// Leave a symbol empty if that side isn't isolated margin.
+func TransferSymbols(from Symbol, to Symbol) TransferOption {
+	return func(v url.Values) {
+		if from != zeroSymbol {
+			param("fromSymbol", from.UpperCase())(v)
+		}
+
+		if to != zeroSymbol {
+			param("toSymbol", to.UpperCase())(v)
+		}
+	}
+}
+
+// Transfers lists transfers of type typ. Without options, transfers from
+// the last 7 days are returned. You can refine the query with StartTime()
+// and EndTime(), and page through the results with page, starting at 1,
+// and size, at most 100. The total number of transfers is returned along
+// with the page.
+func (c *Client) Transfers(typ TransferType, page int, size int, options ...QueryFunc) ([]Transfer, int, error) {
+	var result struct {
+		Total int        `json:"total"`
+		Rows  []Transfer `json:"rows"`
+	}
+
+	err := c.signedCall(&result, "GET", "/sapi/v1/asset/transfer",
+		param("type", typ),
+		param("current", page),
+		param("size", size),
+		newQuery(options).params(),
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return result.Rows, result.Total, nil
+}
@@ -0,0 +1,46 @@
+package binance
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// TransferAccount is a wallet of a sub-account, used when transferring
+// between sub-accounts.
+type TransferAccount string
+
+// The different wallets of a sub-account.
+const (
+	TransferAccountSpot           TransferAccount = "SPOT"
+	TransferAccountUSDTFuture     TransferAccount = "USDT_FUTURE"
+	TransferAccountCoinFuture     TransferAccount = "COIN_FUTURE"
+	TransferAccountMargin         TransferAccount = "MARGIN"
+	TransferAccountIsolatedMargin TransferAccount = "ISOLATED_MARGIN"
+)
+
+// UnmarshalJSON implements json.Unmarshaler while making sure only enums
+// that we know about end up in a TransferAccount variable.
+func (t *TransferAccount) UnmarshalJSON(data []byte) error {
+	s := ""
+	err := json.Unmarshal(data, &s)
+	if err != nil {
+		return err
+	}
+
+	account := TransferAccount(s)
+
+	switch account {
+	case TransferAccountSpot, TransferAccountUSDTFuture, TransferAccountCoinFuture,
+		TransferAccountMargin, TransferAccountIsolatedMargin:
+		*t = account
+	default:
+		return fmt.Errorf("%s is not a valid transfer account", s)
+	}
+
+	return nil
+}
+
+// String implement Stringer.
+func (t TransferAccount) String() string {
+	return string(t)
+}
@@ -0,0 +1,42 @@
+package binance
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// TransferStatus is the status of a transfer.
+type TransferStatus string
+
+// The different states of a transfer.
+const (
+	TransferPending   TransferStatus = "PENDING"
+	TransferConfirmed TransferStatus = "CONFIRMED"
+	TransferFailed    TransferStatus = "FAILED"
+)
+
+// UnmarshalJSON implements json.Unmarshaler while making sure only enums
+// that we know about end up in a TransferStatus variable.
+func (t *TransferStatus) UnmarshalJSON(data []byte) error {
+	s := ""
+	err := json.Unmarshal(data, &s)
+	if err != nil {
+		return err
+	}
+
+	status := TransferStatus(s)
+
+	switch status {
+	case TransferPending, TransferConfirmed, TransferFailed:
+		*t = status
+	default:
+		return fmt.Errorf("%s is not a valid transfer status", s)
+	}
+
+	return nil
+}
+
+// String implement Stringer.
+func (t TransferStatus) String() string {
+	return string(t)
+}
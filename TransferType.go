@@ -0,0 +1,69 @@
+package binance
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// TransferType is the direction of a transfer between the wallets of an
+// account. MAIN is the spot wallet, UMFUTURE the USDⓈ-M futures wallet and
+// CMFUTURE the COIN-M futures wallet.
+type TransferType string
+
+// The different transfer directions.
+const (
+	TransferMainToUMFuture           TransferType = "MAIN_UMFUTURE"
+	TransferMainToCMFuture           TransferType = "MAIN_CMFUTURE"
+	TransferMainToMargin             TransferType = "MAIN_MARGIN"
+	TransferMainToFunding            TransferType = "MAIN_FUNDING"
+	TransferUMFutureToMain           TransferType = "UMFUTURE_MAIN"
+	TransferUMFutureToMargin         TransferType = "UMFUTURE_MARGIN"
+	TransferUMFutureToFunding        TransferType = "UMFUTURE_FUNDING"
+	TransferCMFutureToMain           TransferType = "CMFUTURE_MAIN"
+	TransferCMFutureToMargin         TransferType = "CMFUTURE_MARGIN"
+	TransferCMFutureToFunding        TransferType = "CMFUTURE_FUNDING"
+	TransferMarginToMain             TransferType = "MARGIN_MAIN"
+	TransferMarginToUMFuture         TransferType = "MARGIN_UMFUTURE"
+	TransferMarginToCMFuture         TransferType = "MARGIN_CMFUTURE"
+	TransferMarginToFunding          TransferType = "MARGIN_FUNDING"
+	TransferMarginToIsolatedMargin   TransferType = "MARGIN_ISOLATEDMARGIN"
+	TransferIsolatedMarginToMargin   TransferType = "ISOLATEDMARGIN_MARGIN"
+	TransferIsolatedMarginToIsolated TransferType = "ISOLATEDMARGIN_ISOLATEDMARGIN"
+	TransferFundingToMain            TransferType = "FUNDING_MAIN"
+	TransferFundingToUMFuture        TransferType = "FUNDING_UMFUTURE"
+	TransferFundingToCMFuture        TransferType = "FUNDING_CMFUTURE"
+	TransferFundingToMargin          TransferType = "FUNDING_MARGIN"
+)
+
+// UnmarshalJSON implements json.Unmarshaler while making sure only enums
+// that we know about end up in a TransferType variable.
+func (t *TransferType) UnmarshalJSON(data []byte) error {
+	s := ""
+	err := json.Unmarshal(data, &s)
+	if err != nil {
+		return err
+	}
+
+	typ := TransferType(s)
+
+	switch typ {
+	case TransferMainToUMFuture, TransferMainToCMFuture, TransferMainToMargin,
+		TransferMainToFunding, TransferUMFutureToMain, TransferUMFutureToMargin,
+		TransferUMFutureToFunding, TransferCMFutureToMain, TransferCMFutureToMargin,
+		TransferCMFutureToFunding, TransferMarginToMain, TransferMarginToUMFuture,
+		TransferMarginToCMFuture, TransferMarginToFunding, TransferMarginToIsolatedMargin,
+		TransferIsolatedMarginToMargin, TransferIsolatedMarginToIsolated,
+		TransferFundingToMain, TransferFundingToUMFuture, TransferFundingToCMFuture,
+		TransferFundingToMargin:
+		*t = typ
+	default:
+		return fmt.Errorf("%s is not a valid transfer type", s)
+	}
+
+	return nil
+}
+
+// String implement Stringer.
+func (t TransferType) String() string {
+	return string(t)
+}
@@ -0,0 +1,68 @@
+package binance
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTransfers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+
+		switch r.Method + " " + r.URL.Path {
+		case "POST /sapi/v1/asset/transfer":
+			if q.Get("type") != "MARGIN_ISOLATEDMARGIN" || q.Get("toSymbol") != "BTCUSDT" || q.Get("fromSymbol") != "" {
+				t.Errorf("got transfer %s", r.URL.RawQuery)
+			}
+
+			fmt.Fprint(w, `{"tranId":13526853623}`)
+
+		case "GET /sapi/v1/asset/transfer":
+			fmt.Fprint(w, `{"total":2,"rows":[{"asset":"USDT","amount":"1","type":"MAIN_UMFUTURE","status":"CONFIRMED","tranId":11415955596,"timestamp":1544433328000}]}`)
+
+		case "GET /sapi/v3/sub-account/assets":
+			fmt.Fprint(w, `{"balances":[{"asset":"ADA","free":10000,"locked":0.5}]}`)
+
+		case "POST /sapi/v1/sub-account/universalTransfer":
+			if q.Get("fromAccountType") != "SPOT" || q.Get("toEmail") != "sub@example.com" || q.Get("fromEmail") != "" {
+				t.Errorf("got transfer %s", r.URL.RawQuery)
+			}
+
+			fmt.Fprint(w, `{"tranId":11945860693,"clientTranId":"test"}`)
+
+		default:
+			t.Errorf("unexpected request for %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(APIKey("key"), APISecret("secret"), BaseURL(server.URL))
+
+	id, err := client.UniversalTransfer(TransferMarginToIsolatedMargin, "USDT", "10", TransferSymbols("", "btcusdt"))
+	if err != nil || id != 13526853623 {
+		t.Errorf("UniversalTransfer returned %d %v", id, err)
+	}
+
+	transfers, total, err := client.Transfers(TransferMainToUMFuture, 1, 10)
+	if err != nil || total != 2 || len(transfers) != 1 || transfers[0].Status != TransferConfirmed {
+		t.Errorf("Transfers returned %+v %d %v", transfers, total, err)
+	}
+
+	balances, err := client.SubAccountBalances("sub@example.com")
+	if err != nil || fmt.Sprint(balances) != "[{ADA 10000 0.5}]" {
+		t.Errorf("SubAccountBalances returned %v %v", balances, err)
+	}
+
+	id, err = client.TransferSubAccount(&SubAccountTransfer{
+		ToEmail:     "sub@example.com",
+		FromAccount: TransferAccountSpot,
+		ToAccount:   TransferAccountSpot,
+		Asset:       "USDT",
+		Amount:      "10",
+	})
+	if err != nil || id != 11945860693 {
+		t.Errorf("TransferSubAccount returned %d %v", id, err)
+	}
+}
@@ -0,0 +1,235 @@
+package binance
+
+import (
+	"encoding/json"
+)
+
+// ExecutionReportEvent is pushed on the user data stream every time an order
+// is created, updated, filled, canceled or expires.
+type ExecutionReportEvent struct {
+	EventType                string                  `json:"e"`
+	EventTime                Time                    `json:"E"`
+	Symbol                   Symbol                  `json:"s"`
+	ClientOrderID            string                  `json:"c"`
+	Side                     OrderSide               `json:"S"`
+	Type                     OrderType               `json:"o"`
+	TimeInForce              TimeInForce             `json:"f"`
+	Quantity                 Value                   `json:"q"`
+	Price                    Value                   `json:"p"`
+	StopPrice                Value                   `json:"P"`
+	IcebergQuantity          Value                   `json:"F"`
+	OrderListID              int                     `json:"g"`
+	OriginalClientOrderID    string                  `json:"C"`
+	ExecutionType            ExecutionType           `json:"x"`
+	Status                   OrderStatus             `json:"X"`
+	RejectReason             string                  `json:"r"`
+	OrderID                  int                     `json:"i"`
+	LastExecutedQuantity     Value                   `json:"l"`
+	CumulativeFilledQuantity Value                   `json:"z"`
+	LastExecutedPrice        Value                   `json:"L"`
+	Commission               Value                   `json:"n"`
+	CommissionAsset          string                  `json:"N"` // FIXME: type
+	TransactionTime          Time                    `json:"T"`
+	TradeID                  int64                   `json:"t"`
+	Working                  bool                    `json:"w"`
+	Maker                    bool                    `json:"m"`
+	Created                  Time                    `json:"O"`
+	CumulativeQuoteQuantity  Value                   `json:"Z"`
+	LastQuoteQuantity        Value                   `json:"Y"`
+	QuoteOrderQuantity       Value                   `json:"Q"`
+	WorkingTime              Time                    `json:"W"`
+	PreventedMatchID         int64                   `json:"v"`
+	PreventedQuantity        Value                   `json:"A"`
+	LastPreventedQuantity    Value                   `json:"B"`
+	TradeGroupID             int64                   `json:"u"`
+	CounterOrderID           int64                   `json:"U"`
+	TrailingDelta            int                     `json:"d"`
+	TrailingTime             Time                    `json:"D"`
+	StrategyID               int64                   `json:"j"`
+	StrategyType             int                     `json:"J"`
+	SelfTradePreventionMode  SelfTradePreventionMode `json:"V"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Binance sends a few fields
+// documented as "ignore", we have to catch them here, or encoding/json will
+// happily match them case-insensitively to other fields.
+func (e *ExecutionReportEvent) UnmarshalJSON(data []byte) error {
+	type report ExecutionReportEvent
+
+	var proxy struct {
+		report
+		IgnoreI json.RawMessage `json:"I"`
+		IgnoreM json.RawMessage `json:"M"`
+	}
+
+	err := json.Unmarshal(data, &proxy)
+	if err != nil {
+		return err
+	}
+
+	*e = ExecutionReportEvent(proxy.report)
+
+	return nil
+}
+
+// Order returns the state of the order after the execution described by e.
+func (e *ExecutionReportEvent) Order() Order {
+	clientOrderID := e.ClientOrderID
+	if e.OriginalClientOrderID != "" {
+		clientOrderID = e.OriginalClientOrderID
+	}
+
+	return Order{
+		Symbol:                   e.Symbol,
+		ID:                       e.OrderID,
+		OrderListID:              e.OrderListID,
+		ClientOrderID:            clientOrderID,
+		Price:                    e.Price,
+		Quantity:                 e.Quantity,
+		ExecutedQuantity:         e.CumulativeFilledQuantity,
+		CummulativeQuoteQuantity: e.CumulativeQuoteQuantity,
+		Status:                   e.Status,
+		TimeInForce:              e.TimeInForce,
+		Type:                     e.Type,
+		Side:                     e.Side,
+		StopPrice:                e.StopPrice,
+		IcebergQuantity:          e.IcebergQuantity,
+		Time:                     e.Created,
+		Updated:                  e.TransactionTime,
+		Working:                  e.Working,
+		QuoteOrderQuantity:       e.QuoteOrderQuantity,
+		TrailingDelta:            e.TrailingDelta,
+		StrategyID:               e.StrategyID,
+		StrategyType:             e.StrategyType,
+		SelfTradePreventionMode:  e.SelfTradePreventionMode,
+	}
+}
+
+// Fill returns the trade reported by e. It's only meaningful when
+// ExecutionType is ExecutionTypeTrade.
+func (e *ExecutionReportEvent) Fill() Fill {
+	return Fill{
+		Price:           e.LastExecutedPrice,
+		Quantity:        e.LastExecutedQuantity,
+		Commission:      e.Commission,
+		CommissionAsset: e.CommissionAsset,
+		TradeID:         e.TradeID,
+	}
+}
+
+// AccountPositionEvent is pushed on the user data stream when the balance of
+// one or more assets changed. Only changed assets are included.
+type AccountPositionEvent struct {
+	EventType  string `json:"e"`
+	EventTime  Time   `json:"E"`
+	LastUpdate Time   `json:"u"`
+	Balances   []struct {
+		Asset  string `json:"a"`
+		Free   Value  `json:"f"`
+		Locked Value  `json:"l"`
+	} `json:"B"`
+}
+
+// BalanceUpdateEvent is pushed on the user data stream on deposits,
+// withdrawals and transfers between accounts.
+type BalanceUpdateEvent struct {
+	EventType string `json:"e"`
+	EventTime Time   `json:"E"`
+	Asset     string `json:"a"`
+	Delta     Value  `json:"d"`
+	ClearTime Time   `json:"T"`
+}
+
+// ListStatusEvent is pushed on the user data stream when an order list
+// changes status.
+type ListStatusEvent struct {
+	EventType         string          `json:"e"`
+	EventTime         Time            `json:"E"`
+	Symbol            Symbol          `json:"s"`
+	OrderListID       int             `json:"g"`
+	ContingencyType   ContingencyType `json:"c"`
+	ListStatusType    ListStatusType  `json:"l"`
+	ListOrderStatus   ListOrderStatus `json:"L"`
+	RejectReason      string          `json:"r"`
+	ListClientOrderID string          `json:"C"`
+	TransactionTime   Time            `json:"T"`
+	Orders            []struct {
+		Symbol        Symbol `json:"s"`
+		OrderID       int    `json:"i"`
+		ClientOrderID string `json:"c"`
+	} `json:"O"`
+}
+
+// ListenKeyExpiredEvent is pushed on the user data stream when the listen
+// key expired. No more events will be sent using the key.
+type ListenKeyExpiredEvent struct {
+	EventType string `json:"e"`
+	EventTime Time   `json:"E"`
+	ListenKey string `json:"listenKey"`
+}
+
+// UnknownEvent is returned for events this package doesn't know about, so
+// new event types from Binance don't break reading the stream. Data is the
+// event as received.
+type UnknownEvent struct {
+	EventType string
+	EventTime Time
+	Data      json.RawMessage
+}
+
+// unknownEvent returns data as an *UnknownEvent.
+func unknownEvent(eventType string, data []byte) (*UnknownEvent, error) {
+	// "e" must be declared, or it will be matched to "E".
+	var proxy struct {
+		EventType string `json:"e"`
+		EventTime Time   `json:"E"`
+	}
+
+	err := json.Unmarshal(data, &proxy)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UnknownEvent{
+		EventType: eventType,
+		EventTime: proxy.EventTime,
+		Data:      append(json.RawMessage(nil), data...),
+	}, nil
+}
+
+// userDataEvent will decode an event from the user data stream.
+func userDataEvent(data []byte) (interface{}, error) {
+	var proxy struct {
+		EventType string          `json:"e"`
+		EventTime json.RawMessage `json:"E"`
+	}
+
+	err := json.Unmarshal(data, &proxy)
+	if err != nil {
+		return nil, err
+	}
+
+	var target interface{}
+
+	switch proxy.EventType {
+	case "executionReport":
+		target = new(ExecutionReportEvent)
+	case "outboundAccountPosition":
+		target = new(AccountPositionEvent)
+	case "balanceUpdate":
+		target = new(BalanceUpdateEvent)
+	case "listStatus":
+		target = new(ListStatusEvent)
+	case "listenKeyExpired":
+		target = new(ListenKeyExpiredEvent)
+	default:
+		return unknownEvent(proxy.EventType, data)
+	}
+
+	err = json.Unmarshal(data, target)
+	if err != nil {
+		return nil, err
+	}
+
+	return target, nil
+}
@@ -0,0 +1,70 @@
+package binance
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestUserDataEventExecutionReport(t *testing.T) {
+	data := `{"e":"executionReport","E":1499405658658,"s":"ETHBTC","c":"mUvoqJxFIILMdfAW5iGSOW","S":"BUY","o":"LIMIT","f":"GTC","q":"1.00000000","p":"0.10264410","P":"0.00000000","F":"0.00000000","g":-1,"C":"","x":"TRADE","X":"PARTIALLY_FILLED","r":"NONE","i":4293153,"l":"0.40000000","z":"0.60000000","L":"0.10264410","n":"0.00010000","N":"BNB","T":1499405658657,"t":12,"I":8641984,"w":false,"m":true,"M":false,"O":1499405658657,"Z":"0.06158646","Y":"0.04105764","Q":"0.00000000","W":1499405658657,"V":"NONE"}`
+
+	event, err := userDataEvent([]byte(data))
+	if err != nil {
+		t.Fatalf("userDataEvent failed: %s", err.Error())
+	}
+
+	report, ok := event.(*ExecutionReportEvent)
+	if !ok {
+		t.Fatalf("userDataEvent returned %T, expected *ExecutionReportEvent", event)
+	}
+
+	// "I" and "M" must not leak into "i" and "m".
+	if report.OrderID != 4293153 || !report.Maker {
+		t.Errorf("Ignored fields leaked: %+v", report)
+	}
+
+	if report.ExecutionType != ExecutionTypeTrade || report.LastExecutedQuantity != "0.40000000" {
+		t.Errorf("Wrong execution decoded: %+v", report)
+	}
+
+	order := report.Order()
+	if order.ID != 4293153 || order.Status != PartiallyFilled || order.ExecutedQuantity != "0.60000000" ||
+		order.CummulativeQuoteQuantity != "0.06158646" || order.ClientOrderID != "mUvoqJxFIILMdfAW5iGSOW" ||
+		order.OrderListID != -1 || order.Side != OrderSideBuy || order.Type != OrderTypeLimit {
+		t.Errorf("Order() returned %+v", order)
+	}
+}
+
+func TestUserDataEventTypes(t *testing.T) {
+	cases := []struct {
+		data     string
+		expected string
+	}{
+		{`{"e":"outboundAccountPosition","E":1564034571105,"u":1564034571073,"B":[{"a":"ETH","f":"10000.000000","l":"0.000000"}]}`, "*binance.AccountPositionEvent"},
+		{`{"e":"balanceUpdate","E":1573200697110,"a":"BTC","d":"100.00000000","T":1573200697068}`, "*binance.BalanceUpdateEvent"},
+		{`{"e":"listStatus","E":1564035303637,"s":"ETHBTC","g":2,"c":"OCO","l":"EXEC_STARTED","L":"EXECUTING","r":"NONE","C":"F4QN4G8DlFATFlIUQ0cjdD","T":1564035303625,"O":[{"s":"ETHBTC","i":17,"c":"AJYsMjErWJesZvqlJCTUgL"}]}`, "*binance.ListStatusEvent"},
+		{`{"e":"listenKeyExpired","E":1576653824250,"listenKey":"OfYGbUzi3PraNagEkdKuFwUHn48brFsItTdsuiIXrucEvD0rhRXZ7I6URWfE8YE8"}`, "*binance.ListenKeyExpiredEvent"},
+		{`{"e":"externalLockUpdate","E":1581557507324,"a":"NEO","d":"10.00000000","T":1581557507268}`, "*binance.UnknownEvent"},
+		{`{"e":"executionReport","E":`, ""},
+	}
+
+	for _, c := range cases {
+		event, err := userDataEvent([]byte(c.data))
+		if c.expected == "" {
+			if err == nil {
+				t.Errorf("userDataEvent did not fail for %s", c.data)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("userDataEvent failed for %s: %s", c.data, err.Error())
+			continue
+		}
+
+		if got := fmt.Sprintf("%T", event); got != c.expected {
+			t.Errorf("userDataEvent returned %s, expected %s", got, c.expected)
+		}
+	}
+}
@@ -0,0 +1,244 @@
+package binance
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/net/websocket"
+)
+
+// userDataKeepAlive is how often we will extend the validity of the listen
+// key. Binance recommends 30 minutes.
+const userDataKeepAlive = 30 * time.Minute
+
+// userDataReconnectAttempts is how many times Read() will try to reconnect
+// before giving up.
+const userDataReconnectAttempts = 8
+
+// EventError is returned by Read() when an event can't be decoded. The
+// stream is still usable, and Read() can be called again.
+type EventError struct {
+	Data []byte
+	Err  error
+}
+
+// Error implements error.
+func (e *EventError) Error() string {
+	return fmt.Sprintf("unable to decode user data event: %s", e.Err.Error())
+}
+
+// UserDataStream is a managed stream of events concerning the account. The
+// listen key will be kept alive in the background, and the stream will be
+// reconnected using a new listen key if the old one expires.
+type UserDataStream struct {
+	client *Client
+	keys   listenKeys
+	decode func([]byte) (interface{}, error)
+	done   chan struct{}
+
+	mu        sync.Mutex
+	listenKey string
+	conn      *websocket.Conn
+	closed    bool
+}
+
+// UserDataStream will create a listen key and open a websocket stream for
+// events concerning the account. You can use the Read() method when reading
+// from the stream. You should call Close() when done.
+func (c *Client) UserDataStream() (*UserDataStream, error) {
+	return c.userDataStream(spotListenKeys, userDataEvent)
+}
+
+// userDataStream will open a user data stream using listen keys from keys.
+// Events are decoded by decode.
+func (c *Client) userDataStream(keys listenKeys, decode func([]byte) (interface{}, error)) (*UserDataStream, error) {
+	listenKey, err := keys.create(c)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &UserDataStream{
+		client:    c,
+		keys:      keys,
+		decode:    decode,
+		done:      make(chan struct{}),
+		listenKey: listenKey,
+	}
+
+	s.conn, err = s.dial(listenKey)
+	if err != nil {
+		_ = keys.close(c, listenKey)
+		return nil, err
+	}
+
+	go s.keepAlive()
+
+	return s, nil
+}
+
+// ListenKey returns the listen key currently in use.
+func (s *UserDataStream) ListenKey() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.listenKey
+}
+
+// Read an event from the stream. This will block until an event is ready.
+// The event will be one of *ExecutionReportEvent, *AccountPositionEvent,
+// *BalanceUpdateEvent, *ListStatusEvent, *ListenKeyExpiredEvent, or
+// *UnknownEvent for event types this package doesn't know about. Lost
+// connections and expired listen keys are handled transparently, but a
+// *ListenKeyExpiredEvent will still be returned as events could have been
+// lost. An *EventError is returned if an event can't be decoded, other
+// errors mean the stream is closed or can't be reconnected. Futures streams
+// return the events described by Futures.UserDataStream().
+func (s *UserDataStream) Read() (interface{}, error) {
+	for {
+		s.mu.Lock()
+		conn := s.conn
+		s.mu.Unlock()
+
+		var data []byte
+		err := websocket.Message.Receive(conn, &data)
+		if err != nil {
+			err = s.reconnect(false)
+			if err != nil {
+				return nil, err
+			}
+
+			continue
+		}
+
+		event, err := s.decode(data)
+		if err != nil {
+			return nil, &EventError{Data: data, Err: err}
+		}
+
+		if _, expired := event.(*ListenKeyExpiredEvent); expired {
+			err = s.reconnect(true)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return event, nil
+	}
+}
+
+// Close will close the stream and the listen key.
+func (s *UserDataStream) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return nil
+	}
+
+	s.closed = true
+	close(s.done)
+
+	err := s.conn.Close()
+
+	closeErr := s.keys.close(s.client, s.listenKey)
+	if err == nil {
+		err = closeErr
+	}
+
+	return err
+}
+
+// dial will open a websocket for listenKey.
+func (s *UserDataStream) dial(listenKey string) (*websocket.Conn, error) {
+	URL := fmt.Sprintf("%s/ws/%s", s.client.streamBaseURL, listenKey)
+
+	return websocket.Dial(URL, "", "http://localhost/")
+}
+
+// reconnect will replace the current connection. If the listen key is
+// expired, or can't be kept alive, a new key will be created.
+func (s *UserDataStream) reconnect(expired bool) error {
+	backoff := time.Second
+
+	for attempt := 0; attempt < userDataReconnectAttempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-s.done:
+			case <-time.After(backoff):
+			}
+
+			backoff *= 2
+		}
+
+		s.mu.Lock()
+		closed := s.closed
+		listenKey := s.listenKey
+		s.mu.Unlock()
+
+		if closed {
+			return errors.New("stream closed")
+		}
+
+		if expired || s.keys.keepAlive(s.client, listenKey) != nil {
+			key, err := s.keys.create(s.client)
+			if err != nil {
+				continue
+			}
+
+			listenKey = key
+			expired = false
+		}
+
+		conn, err := s.dial(listenKey)
+		if err != nil {
+			continue
+		}
+
+		s.mu.Lock()
+		if s.closed {
+			s.mu.Unlock()
+			conn.Close()
+			return errors.New("stream closed")
+		}
+
+		oldKey := s.listenKey
+		s.conn.Close()
+		s.conn = conn
+		s.listenKey = listenKey
+		s.mu.Unlock()
+
+		if oldKey != listenKey {
+			_ = s.keys.close(s.client, oldKey)
+		}
+
+		return nil
+	}
+
+	return errors.New("unable to reconnect user data stream")
+}
+
+// keepAlive will keep the listen key alive until the stream is closed. If the
+// key can't be kept alive, we close the connection, to let Read() reconnect.
+func (s *UserDataStream) keepAlive() {
+	ticker := time.NewTicker(userDataKeepAlive)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+		}
+
+		s.mu.Lock()
+		listenKey := s.listenKey
+		conn := s.conn
+		s.mu.Unlock()
+
+		if s.keys.keepAlive(s.client, listenKey) != nil {
+			conn.Close()
+		}
+	}
+}
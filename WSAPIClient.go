@@ -0,0 +1,459 @@
+package binance
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/websocket"
+)
+
+// WSAPIClient is a client talking to the Binance websocket API. All requests
+// are sent over a single persistent connection, which saves a round trip or
+// two compared to the REST API. It's safe to use from multiple goroutines
+// and requests can be in flight concurrently.
+type WSAPIClient struct {
+	client  *Client
+	conn    *websocket.Conn
+	timeout time.Duration
+
+	// sendLock serializes writes to conn.
+	sendLock sync.Mutex
+
+	mu         sync.Mutex
+	pending    map[string]chan *wsAPIResponse
+	nextID     uint64
+	loggedOn   bool
+	rateLimits []RateLimit
+	err        error
+}
+
+// WSAPITimeoutError is returned when the websocket API doesn't answer a
+// request in time. It implements net.Error, with Timeout() returning true.
+// The request may still have been processed by Binance.
+type WSAPITimeoutError struct {
+	Method string
+	Wait   time.Duration
+}
+
+// Error implements error.
+func (e *WSAPITimeoutError) Error() string {
+	return fmt.Sprintf("no response to %s within %s", e.Method, e.Wait)
+}
+
+// Timeout implements net.Error.
+func (e *WSAPITimeoutError) Timeout() bool {
+	return true
+}
+
+// Temporary implements net.Error.
+func (e *WSAPITimeoutError) Temporary() bool {
+	return true
+}
+
+// wsAPIRequest is a request as sent to the websocket API.
+type wsAPIRequest struct {
+	ID     string                 `json:"id"`
+	Method string                 `json:"method"`
+	Params map[string]interface{} `json:"params,omitempty"`
+}
+
+// wsAPIResponse is a response as received from the websocket API.
+type wsAPIResponse struct {
+	ID         string          `json:"id"`
+	Status     int             `json:"status"`
+	Result     json.RawMessage `json:"result"`
+	Error      *APIError       `json:"error"`
+	RateLimits []RateLimit     `json:"rateLimits"`
+}
+
+// wsAPIIntParams lists the parameters the websocket API wants as numbers.
+// Everything else is sent as strings.
+var wsAPIIntParams = map[string]bool{
+	"timestamp":     true,
+	"recvWindow":    true,
+	"orderId":       true,
+	"orderListId":   true,
+	"cancelOrderId": true,
+	"fromId":        true,
+	"startTime":     true,
+	"endTime":       true,
+	"limit":         true,
+	"strategyId":    true,
+	"strategyType":  true,
+	"trailingDelta": true,
+}
+
+// WSAPIClient will connect to the websocket API using the credentials of c.
+// The websocket API is not available when paper trading. You should call
+// Close() when done.
+func (c *Client) WSAPIClient() (*WSAPIClient, error) {
+	if c.paper != nil {
+		return nil, errors.New("the websocket API is not simulated by the paper account")
+	}
+
+	conn, err := websocket.Dial(c.wsAPIBaseURL, "", "http://localhost/")
+	if err != nil {
+		return nil, err
+	}
+
+	w := &WSAPIClient{
+		client:  c,
+		conn:    conn,
+		timeout: c.wsAPITimeout,
+		pending: make(map[string]chan *wsAPIResponse),
+	}
+
+	go w.readLoop()
+
+	return w, nil
+}
+
+// Close will close the connection. Requests in flight will fail.
+func (w *WSAPIClient) Close() error {
+	return w.conn.Close()
+}
+
+// RateLimits returns the rate limit usage as reported by the latest
+// response.
+func (w *WSAPIClient) RateLimits() []RateLimit {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.rateLimits
+}
+
+// readLoop will read responses and hand them to the waiting callers until
+// the connection fails.
+func (w *WSAPIClient) readLoop() {
+	for {
+		response := &wsAPIResponse{}
+
+		err := websocket.JSON.Receive(w.conn, response)
+		if err != nil {
+			w.mu.Lock()
+			w.err = err
+			for id, ch := range w.pending {
+				close(ch)
+				delete(w.pending, id)
+			}
+			w.mu.Unlock()
+
+			return
+		}
+
+		w.mu.Lock()
+		if response.RateLimits != nil {
+			w.rateLimits = response.RateLimits
+		}
+
+		ch, found := w.pending[response.ID]
+		delete(w.pending, response.ID)
+		w.mu.Unlock()
+
+		if found {
+			ch <- response
+		}
+	}
+}
+
+// request will send a request and wait for the response, for at most the
+// timeout set by WSAPITimeout(). The result will be decoded into target if
+// not nil.
+func (w *WSAPIClient) request(target interface{}, method string, params map[string]interface{}) error {
+	ch := make(chan *wsAPIResponse, 1)
+
+	w.mu.Lock()
+	if w.err != nil {
+		err := w.err
+		w.mu.Unlock()
+		return err
+	}
+
+	w.nextID++
+	id := strconv.FormatUint(w.nextID, 10)
+	w.pending[id] = ch
+	w.mu.Unlock()
+
+	w.sendLock.Lock()
+	err := websocket.JSON.Send(w.conn, &wsAPIRequest{
+		ID:     id,
+		Method: method,
+		Params: params,
+	})
+	w.sendLock.Unlock()
+
+	if err != nil {
+		w.mu.Lock()
+		delete(w.pending, id)
+		w.mu.Unlock()
+
+		return err
+	}
+
+	var timeout <-chan time.Time
+	if w.timeout > 0 {
+		timer := time.NewTimer(w.timeout)
+		defer timer.Stop()
+
+		timeout = timer.C
+	}
+
+	var response *wsAPIResponse
+	var ok bool
+
+	select {
+	case response, ok = <-ch:
+	case <-timeout:
+		w.mu.Lock()
+		delete(w.pending, id)
+		w.mu.Unlock()
+
+		return &WSAPITimeoutError{Method: method, Wait: w.timeout}
+	}
+
+	if !ok {
+		w.mu.Lock()
+		err = w.err
+		w.mu.Unlock()
+
+		return err
+	}
+
+	if response.Error != nil {
+		response.Error.StatusCode = response.Status
+		return response.Error
+	}
+
+	if response.Status >= 400 {
+		return &APIError{StatusCode: response.Status}
+	}
+
+	if target == nil {
+		return nil
+	}
+
+	return json.Unmarshal(response.Result, target)
+}
+
+// wsAPIParams will convert params to the format used by the websocket API.
+func wsAPIParams(values url.Values) map[string]interface{} {
+	m := make(map[string]interface{}, len(values))
+
+	for key := range values {
+		value := values.Get(key)
+
+		if wsAPIIntParams[key] {
+			i, err := strconv.ParseInt(value, 10, 64)
+			if err == nil {
+				m[key] = i
+				continue
+			}
+		}
+
+		m[key] = value
+	}
+
+	return m
+}
+
+// wsAPIPayload returns the payload to sign for values. The websocket API
+// wants the parameters sorted by key and not escaped.
+func wsAPIPayload(values url.Values) string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	pairs := make([]string, len(keys))
+	for i, key := range keys {
+		pairs[i] = key + "=" + values.Get(key)
+	}
+
+	return strings.Join(pairs, "&")
+}
+
+// call will call a method not requiring authentication.
+func (w *WSAPIClient) call(target interface{}, method string, params ...func(url.Values)) error {
+	values := url.Values{}
+	for _, p := range params {
+		p(values)
+	}
+
+	return w.request(target, method, wsAPIParams(values))
+}
+
+// signedCall will call a method requiring a signature. If the session is
+// logged on, the signature is left out.
+func (w *WSAPIClient) signedCall(target interface{}, method string, params ...func(url.Values)) error {
+	c := w.client
+
+	w.mu.Lock()
+	loggedOn := w.loggedOn
+	w.mu.Unlock()
+
+	if !loggedOn && c.apiSecret == "" && c.ed25519Key == nil {
+		return errors.New("no API secret set")
+	}
+
+	values := url.Values{}
+	for _, p := range params {
+		p(values)
+	}
+
+	values.Set("timestamp", strconv.FormatInt(time.Now().UnixNano()/int64(time.Millisecond), 10))
+
+	if !loggedOn {
+		values.Set("apiKey", c.apiKey)
+		values.Set("signature", c.sign(wsAPIPayload(values)))
+	}
+
+	return w.request(target, method, wsAPIParams(values))
+}
+
+// Logon will authenticate the connection using the Ed25519 key. After
+// logging on, requests no longer need to be signed individually.
+func (w *WSAPIClient) Logon() error {
+	c := w.client
+
+	if c.ed25519Key == nil {
+		return errors.New("logon requires an Ed25519 key")
+	}
+
+	values := url.Values{}
+	values.Set("apiKey", c.apiKey)
+	values.Set("timestamp", strconv.FormatInt(time.Now().UnixNano()/int64(time.Millisecond), 10))
+	values.Set("signature", signEd25519(wsAPIPayload(values), c.ed25519Key))
+
+	err := w.request(nil, "session.logon", wsAPIParams(values))
+	if err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	w.loggedOn = true
+	w.mu.Unlock()
+
+	return nil
+}
+
+// Logout will forget the authentication of the session. Requests will be
+// signed individually again.
+func (w *WSAPIClient) Logout() error {
+	err := w.request(nil, "session.logout", nil)
+	if err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	w.loggedOn = false
+	w.mu.Unlock()
+
+	return nil
+}
+
+// Ping will ping the websocket API and return a RTT duration and an error if
+// something went wrong.
+func (w *WSAPIClient) Ping() (time.Duration, error) {
+	t := time.Now()
+	err := w.call(nil, "ping")
+
+	return time.Since(t), err
+}
+
+// SubmitOrder will submit order for processing. order will be updated with
+// the response from Binance. If order.ClientOrderID is empty, a new ID is
+// generated. If no response arrives in time, a *WSAPITimeoutError is
+// returned and the outcome is unknown. The order should be looked up by its
+// client order ID before it's submitted again.
+func (w *WSAPIClient) SubmitOrder(order *Order) error {
+	if order.ClientOrderID == "" {
+		order.ClientOrderID = w.client.NewClientOrderID()
+	}
+
+	err := order.Validate()
+	if err != nil {
+		return err
+	}
+
+	return w.signedCall(order, "order.place", orderParams(order)...)
+}
+
+// SubmitTestOrder will submit a test order.
+func (w *WSAPIClient) SubmitTestOrder(order *Order) error {
+	err := order.Validate()
+	if err != nil {
+		return err
+	}
+
+	return w.signedCall(nil, "order.test", orderParams(order)...)
+}
+
+// CancelOrder cancels a live order.
+func (w *WSAPIClient) CancelOrder(symbol Symbol, clientOrderID string, id int) (*Order, error) {
+	params, err := orderIDParams(symbol, clientOrderID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var order Order
+	err = w.signedCall(&order, "order.cancel", params...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &order, nil
+}
+
+// OrderStatus queries the status of an order.
+func (w *WSAPIClient) OrderStatus(symbol Symbol, clientOrderID string, id int) (*Order, error) {
+	params, err := orderIDParams(symbol, clientOrderID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var order Order
+	err = w.signedCall(&order, "order.status", params...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &order, nil
+}
+
+// OpenOrders lists the currently open orders. If symbol is empty, open
+// orders for all symbols are returned.
+func (w *WSAPIClient) OpenOrders(symbol Symbol) ([]Order, error) {
+	params := []func(url.Values){}
+
+	if symbol != zeroSymbol {
+		params = append(params, param("symbol", symbol))
+	}
+
+	results := make([]Order, 0, 100)
+	err := w.signedCall(&results, "openOrders.status", params...)
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// AccountInfo retrieves various information about the account.
+func (w *WSAPIClient) AccountInfo() (*AccountInfo, error) {
+	var info AccountInfo
+	err := w.signedCall(&info, "account.status")
+	if err != nil {
+		return nil, err
+	}
+
+	return &info, nil
+}
@@ -0,0 +1,165 @@
+package binance
+
+import (
+	"encoding/json"
+	"net"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/net/websocket"
+)
+
+// wsAPIServer starts a fake websocket API. Requests are answered in reverse
+// order of arrival in batches of two, to make sure responses are correlated
+// by id.
+func wsAPIServer(t *testing.T) *httptest.Server {
+	handler := websocket.Handler(func(conn *websocket.Conn) {
+		var batch []wsAPIRequest
+
+		for {
+			var req wsAPIRequest
+			err := websocket.JSON.Receive(conn, &req)
+			if err != nil {
+				return
+			}
+
+			batch = append(batch, req)
+			if len(batch) < 2 {
+				continue
+			}
+
+			for i := len(batch) - 1; i >= 0; i-- {
+				req := batch[i]
+				response := map[string]interface{}{
+					"id":         req.ID,
+					"status":     200,
+					"rateLimits": []RateLimit{{Type: "REQUEST_WEIGHT", Interval: "MINUTE", IntervalNum: 1, Limit: 6000, Count: 2}},
+				}
+
+				if req.Params["symbol"] == "FAIL" {
+					response["status"] = 400
+					response["error"] = map[string]interface{}{"code": -2010, "msg": "Account has insufficient balance for requested action."}
+				} else {
+					response["result"] = map[string]interface{}{"symbol": req.Params["symbol"], "orderId": req.Params["orderId"]}
+				}
+
+				_ = websocket.JSON.Send(conn, response)
+			}
+
+			batch = nil
+		}
+	})
+
+	return httptest.NewServer(handler)
+}
+
+func TestWSAPIClientCorrelation(t *testing.T) {
+	server := wsAPIServer(t)
+	defer server.Close()
+
+	client, _ := NewClient(
+		APIKey("key"),
+		APISecret("secret"),
+		WSAPIBaseURL("ws"+strings.TrimPrefix(server.URL, "http")),
+	)
+
+	w, err := client.WSAPIClient()
+	if err != nil {
+		t.Fatalf("WSAPIClient failed: %s", err.Error())
+	}
+	defer w.Close()
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+
+		order, err := w.OrderStatus("BTCUSDT", "", 12)
+		if err != nil {
+			t.Errorf("OrderStatus failed: %s", err.Error())
+			return
+		}
+
+		if order.Symbol != "BTCUSDT" || order.ID != 12 {
+			t.Errorf("Got wrong response: %+v", order)
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+
+		_, err := w.OrderStatus("FAIL", "", 13)
+		apiErr, ok := err.(*APIError)
+		if !ok {
+			t.Errorf("Got %T, expected *APIError", err)
+			return
+		}
+
+		if apiErr.Code != -2010 || apiErr.StatusCode != 400 {
+			t.Errorf("Got wrong error: %+v", apiErr)
+		}
+	}()
+
+	wg.Wait()
+
+	limits := w.RateLimits()
+	if len(limits) != 1 || limits[0].Count != 2 {
+		t.Errorf("Got wrong rate limits: %+v", limits)
+	}
+}
+
+func TestWSAPIClientTimeout(t *testing.T) {
+	server := wsAPIServer(t)
+	defer server.Close()
+
+	client, _ := NewClient(
+		APIKey("key"),
+		APISecret("secret"),
+		WSAPIBaseURL("ws"+strings.TrimPrefix(server.URL, "http")),
+		WSAPITimeout(50*time.Millisecond),
+	)
+
+	w, err := client.WSAPIClient()
+	if err != nil {
+		t.Fatalf("WSAPIClient failed: %s", err.Error())
+	}
+	defer w.Close()
+
+	// The server waits for a second request, which never comes.
+	_, err = w.OrderStatus("BTCUSDT", "", 12)
+	if e, ok := err.(net.Error); !ok || !e.Timeout() || !unknownExecution(err) {
+		t.Fatalf("OrderStatus returned %v, expected a timeout", err)
+	}
+
+	w.mu.Lock()
+	pending := len(w.pending)
+	w.mu.Unlock()
+
+	if pending != 0 {
+		t.Errorf("%d requests still pending", pending)
+	}
+}
+
+func TestWSAPIPayload(t *testing.T) {
+	values := url.Values{}
+	values.Set("symbol", "BTCUSDT")
+	values.Set("newClientOrderId", "a:b/c")
+	values.Set("timestamp", "1655716096498")
+	values.Set("trailingDelta", "100")
+
+	expected := "newClientOrderId=a:b/c&symbol=BTCUSDT&timestamp=1655716096498&trailingDelta=100"
+	if got := wsAPIPayload(values); got != expected {
+		t.Errorf("Got '%s', expected '%s'", got, expected)
+	}
+
+	out, _ := json.Marshal(wsAPIParams(values))
+	expected = `{"newClientOrderId":"a:b/c","symbol":"BTCUSDT","timestamp":1655716096498,"trailingDelta":100}`
+	if string(out) != expected {
+		t.Errorf("Got '%s', expected '%s'", string(out), expected)
+	}
+}
@@ -0,0 +1,246 @@
+package binance
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"regexp"
+)
+
+// AllowedAddress is a destination withdrawals are allowed to. Tag must match
+// exactly, so an address without a tag must have an empty Tag.
+type AllowedAddress struct {
+	Coin    string
+	Network string
+	Address string
+	Tag     string
+}
+
+// WithdrawAllowlist sets the addresses Withdraw() may send to. Without an
+// allowlist, all withdrawals are refused.
+func WithdrawAllowlist(addresses ...AllowedAddress) func(*Client) {
+	return func(c *Client) {
+		c.withdrawAllowlist = append(c.withdrawAllowlist, addresses...)
+	}
+}
+
+// WithdrawRequest describes a withdrawal to make.
+type WithdrawRequest struct {
+	Coin       string
+	Network    string
+	Address    string
+	AddressTag string
+	Amount     Value
+
+	// WithdrawOrderID identifies the withdrawal. If empty, Withdraw() will
+	// set a new unique ID. Retrying a request with the same ID will not
+	// withdraw twice.
+	WithdrawOrderID string
+}
+
+// allowed returns true if the destination of r is on the allowlist.
+func (c *Client) allowed(r *WithdrawRequest) bool {
+	for _, a := range c.withdrawAllowlist {
+		if a.Coin == r.Coin && a.Network == r.Network && a.Address == r.Address && a.Tag == r.AddressTag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkWithdrawAmount will check amount against the limits of network.
+func checkWithdrawAmount(amount Value, network CoinNetwork) error {
+	a, ok := new(big.Rat).SetString(string(amount))
+	if !ok || a.Sign() <= 0 {
+		return fmt.Errorf("amount %s is not a positive number", amount)
+	}
+
+	if min, ok := new(big.Rat).SetString(string(network.WithdrawMin)); ok && a.Cmp(min) < 0 {
+		return fmt.Errorf("amount %s is below the minimum of %s", amount, network.WithdrawMin)
+	}
+
+	if max, ok := new(big.Rat).SetString(string(network.WithdrawMax)); ok && max.Sign() > 0 && a.Cmp(max) > 0 {
+		return fmt.Errorf("amount %s is above the maximum of %s", amount, network.WithdrawMax)
+	}
+
+	if step, ok := new(big.Rat).SetString(string(network.WithdrawIntegerMultiple)); ok && step.Sign() > 0 {
+		if !new(big.Rat).Quo(a, step).IsInt() {
+			return fmt.Errorf("amount %s is not a multiple of %s", amount, network.WithdrawIntegerMultiple)
+		}
+	}
+
+	return nil
+}
+
+// checkWithdraw will check r against the allowlist and the rules of the
+// network.
+func (c *Client) checkWithdraw(r *WithdrawRequest) error {
+	if r.Coin == "" || r.Network == "" || r.Address == "" {
+		return errors.New("coin, network and address must be set")
+	}
+
+	if !c.allowed(r) {
+		return fmt.Errorf("%s address %s on %s is not on the allowlist", r.Coin, r.Address, r.Network)
+	}
+
+	coins, err := c.CoinInfo()
+	if err != nil {
+		return err
+	}
+
+	for _, coin := range coins {
+		if coin.Coin != r.Coin {
+			continue
+		}
+
+		network, found := coin.Network(r.Network)
+		if !found {
+			return fmt.Errorf("%s can't be withdrawn on %s", r.Coin, r.Network)
+		}
+
+		if !coin.WithdrawAllEnable || !network.WithdrawEnable {
+			return fmt.Errorf("withdrawals of %s on %s are disabled", r.Coin, r.Network)
+		}
+
+		if network.AddressRegex != "" {
+			re, err := regexp.Compile(network.AddressRegex)
+			if err != nil {
+				return fmt.Errorf("unable to check %s address: %s", r.Network, err.Error())
+			}
+
+			if !re.MatchString(r.Address) {
+				return fmt.Errorf("%s is not a valid %s address", r.Address, r.Network)
+			}
+		}
+
+		if network.MemoRegex != "" && r.AddressTag != "" {
+			re, err := regexp.Compile(network.MemoRegex)
+			if err != nil {
+				return fmt.Errorf("unable to check %s tag: %s", r.Network, err.Error())
+			}
+
+			if !re.MatchString(r.AddressTag) {
+				return fmt.Errorf("%s is not a valid %s tag", r.AddressTag, r.Network)
+			}
+		}
+
+		return checkWithdrawAmount(r.Amount, network)
+	}
+
+	return fmt.Errorf("unknown coin %s", r.Coin)
+}
+
+// withdrawalByOrderID returns the ID of the withdrawal with withdrawOrderID,
+// or an empty string if it doesn't exist.
+func (c *Client) withdrawalByOrderID(withdrawOrderID string) (string, error) {
+	var withdrawals []Withdrawal
+	err := c.signedCall(&withdrawals, "GET", "/sapi/v1/capital/withdraw/history",
+		param("withdrawOrderId", withdrawOrderID),
+	)
+	if err != nil {
+		return "", err
+	}
+
+	for _, w := range withdrawals {
+		if w.WithdrawOrderID == withdrawOrderID {
+			return w.ID, nil
+		}
+	}
+
+	return "", nil
+}
+
+// previousWithdrawal returns the ID of the withdrawal with withdrawOrderID,
+// or an empty string if it was never submitted. A withdrawal submitted by
+// c with an unknown outcome is refused until it's seen in the history.
+func (c *Client) previousWithdrawal(withdrawOrderID string) (string, error) {
+	id, submitted := c.withdrawn[withdrawOrderID]
+	if id != "" {
+		return id, nil
+	}
+
+	id, err := c.withdrawalByOrderID(withdrawOrderID)
+	if err != nil {
+		return "", err
+	}
+
+	if id != "" {
+		c.withdrawn[withdrawOrderID] = id
+		return id, nil
+	}
+
+	if submitted {
+		return "", fmt.Errorf("withdrawal %s was already submitted and is not in the history yet", withdrawOrderID)
+	}
+
+	return "", nil
+}
+
+// Withdraw will withdraw r.Amount of r.Coin to an address on the allowlist,
+// and return the ID of the withdrawal. Before anything is sent, the
+// destination is checked against the allowlist and the address format of
+// the network, and the amount against the minimum, maximum and step of the
+// network. Withdrawals must be enabled for the network.
+//
+// If a withdrawal with r.WithdrawOrderID already exists, its ID is returned
+// and nothing new is withdrawn. It's safe to retry a withdrawal that failed
+// with an unknown outcome, as long as the same r is used. Withdrawals are
+// only seen by Binance's history after a short while, so until then a retry
+// of a withdrawal submitted by the same client is refused.
+func (c *Client) Withdraw(r *WithdrawRequest) (string, error) {
+	err := c.checkWithdraw(r)
+	if err != nil {
+		return "", err
+	}
+
+	c.withdrawMu.Lock()
+	defer c.withdrawMu.Unlock()
+
+	if c.withdrawn == nil {
+		c.withdrawn = make(map[string]string)
+	}
+
+	if r.WithdrawOrderID == "" {
+		r.WithdrawOrderID = c.NewClientOrderID()
+	} else {
+		id, err := c.previousWithdrawal(r.WithdrawOrderID)
+		if err != nil || id != "" {
+			return id, err
+		}
+	}
+
+	params := []func(url.Values){
+		param("coin", r.Coin),
+		param("network", r.Network),
+		param("address", r.Address),
+		param("amount", r.Amount),
+		param("withdrawOrderId", r.WithdrawOrderID),
+	}
+
+	if r.AddressTag != "" {
+		params = append(params, param("addressTag", r.AddressTag))
+	}
+
+	var result struct {
+		ID string `json:"id"`
+	}
+
+	c.withdrawn[r.WithdrawOrderID] = ""
+
+	err = c.signedCall(&result, "POST", "/sapi/v1/capital/withdraw/apply", params...)
+	if err != nil {
+		// A withdrawal refused by Binance can be retried right away.
+		if e, ok := err.(*APIError); ok && e.StatusCode < http.StatusInternalServerError {
+			delete(c.withdrawn, r.WithdrawOrderID)
+		}
+
+		return "", err
+	}
+
+	c.withdrawn[r.WithdrawOrderID] = result.ID
+
+	return result.ID, nil
+}
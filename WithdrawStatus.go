@@ -0,0 +1,71 @@
+package binance
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// WithdrawStatus is the status of a withdrawal.
+type WithdrawStatus int
+
+// The different states of a withdrawal.
+const (
+	WithdrawEmailSent        WithdrawStatus = 0
+	WithdrawCanceled         WithdrawStatus = 1
+	WithdrawAwaitingApproval WithdrawStatus = 2
+	WithdrawRejected         WithdrawStatus = 3
+	WithdrawProcessing       WithdrawStatus = 4
+	WithdrawFailure          WithdrawStatus = 5
+	WithdrawCompleted        WithdrawStatus = 6
+)
+
+// withdrawStatusNames are used by String().
+var withdrawStatusNames = map[WithdrawStatus]string{
+	WithdrawEmailSent:        "EMAIL_SENT",
+	WithdrawCanceled:         "CANCELED",
+	WithdrawAwaitingApproval: "AWAITING_APPROVAL",
+	WithdrawRejected:         "REJECTED",
+	WithdrawProcessing:       "PROCESSING",
+	WithdrawFailure:          "FAILURE",
+	WithdrawCompleted:        "COMPLETED",
+}
+
+// UnmarshalJSON implements json.Unmarshaler while making sure only enums
+// that we know about end up in a WithdrawStatus variable.
+func (w *WithdrawStatus) UnmarshalJSON(data []byte) error {
+	i := 0
+	err := json.Unmarshal(data, &i)
+	if err != nil {
+		return err
+	}
+
+	status := WithdrawStatus(i)
+
+	if _, found := withdrawStatusNames[status]; !found {
+		return fmt.Errorf("%d is not a valid withdraw status", i)
+	}
+
+	*w = status
+
+	return nil
+}
+
+// Final returns true if the withdrawal can't change status anymore.
+func (w WithdrawStatus) Final() bool {
+	switch w {
+	case WithdrawCanceled, WithdrawRejected, WithdrawFailure, WithdrawCompleted:
+		return true
+	}
+
+	return false
+}
+
+// String implement Stringer.
+func (w WithdrawStatus) String() string {
+	name, found := withdrawStatusNames[w]
+	if !found {
+		return fmt.Sprintf("WithdrawStatus(%d)", int(w))
+	}
+
+	return name
+}
@@ -0,0 +1,105 @@
+package binance
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWithdraw(t *testing.T) {
+	applied := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+
+		switch r.Method + " " + r.URL.Path {
+		case "GET /sapi/v1/capital/config/getall":
+			fmt.Fprint(w, `[{"coin":"BTC","withdrawAllEnable":true,"networkList":[
+				{"network":"BTC","withdrawEnable":true,"addressRegex":"^[13][a-km-zA-HJ-NP-Z1-9]{25,34}$","withdrawMin":"0.001","withdrawMax":"10","withdrawIntegerMultiple":"0.00000001"},
+				{"network":"BNB","withdrawEnable":false,"withdrawMin":"0.00001","withdrawMax":"10"},
+				{"network":"BAD","withdrawEnable":true,"addressRegex":"^[13","withdrawMin":"0.001","withdrawMax":"10"}]}]`)
+
+		case "GET /sapi/v1/capital/withdraw/history":
+			if q.Get("withdrawOrderId") == "done" {
+				fmt.Fprint(w, `[{"id":"existing","withdrawOrderId":"done","status":6}]`)
+				return
+			}
+
+			fmt.Fprint(w, `[]`)
+
+		case "POST /sapi/v1/capital/withdraw/apply":
+			applied++
+
+			if q.Get("withdrawOrderId") == "" || q.Get("amount") != "0.5" {
+				t.Errorf("got withdrawal %s", r.URL.RawQuery)
+			}
+
+			if q.Get("withdrawOrderId") == "lost" {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+
+			fmt.Fprint(w, `{"id":"new"}`)
+
+		default:
+			t.Errorf("unexpected request for %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	address := "1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2"
+
+	client, _ := NewClient(APIKey("key"), APISecret("secret"), BaseURL(server.URL),
+		WithdrawAllowlist(
+			AllowedAddress{Coin: "BTC", Network: "BTC", Address: address},
+			AllowedAddress{Coin: "BTC", Network: "BTC", Address: "not-an-address"},
+			AllowedAddress{Coin: "BTC", Network: "BNB", Address: "bnb1", Tag: "123"},
+			AllowedAddress{Coin: "BTC", Network: "BAD", Address: address},
+		),
+	)
+
+	cases := []struct {
+		request  WithdrawRequest
+		expected string
+	}{
+		{WithdrawRequest{Coin: "BTC", Network: "BTC", Address: "1Other", Amount: "0.5"}, ""},
+		{WithdrawRequest{Coin: "BTC", Address: address, Amount: "0.5"}, ""},
+		{WithdrawRequest{Coin: "BTC", Network: "BNB", Address: "bnb1", AddressTag: "124", Amount: "0.5"}, ""},
+		{WithdrawRequest{Coin: "BTC", Network: "BNB", Address: "bnb1", AddressTag: "123", Amount: "0.5"}, ""},
+		{WithdrawRequest{Coin: "BTC", Network: "BTC", Address: "not-an-address", Amount: "0.5"}, ""},
+		{WithdrawRequest{Coin: "BTC", Network: "BTC", Address: address, Amount: "0.0001"}, ""},
+		{WithdrawRequest{Coin: "BTC", Network: "BTC", Address: address, Amount: "100"}, ""},
+		{WithdrawRequest{Coin: "BTC", Network: "BTC", Address: address, Amount: "0.5000000001"}, ""},
+		{WithdrawRequest{Coin: "BTC", Network: "BTC", Address: address, Amount: "-1"}, ""},
+		{WithdrawRequest{Coin: "BTC", Network: "BTC", Address: address, Amount: "0.5"}, "new"},
+		{WithdrawRequest{Coin: "BTC", Network: "BTC", Address: address, Amount: "0.5", WithdrawOrderID: "done"}, "existing"},
+		{WithdrawRequest{Coin: "BTC", Network: "BTC", Address: address, Amount: "0.5", WithdrawOrderID: "retry"}, "new"},
+		// Known from the first time, even if not in the history yet.
+		{WithdrawRequest{Coin: "BTC", Network: "BTC", Address: address, Amount: "0.5", WithdrawOrderID: "retry"}, "new"},
+		// The outcome is unknown, so the retry is refused.
+		{WithdrawRequest{Coin: "BTC", Network: "BTC", Address: address, Amount: "0.5", WithdrawOrderID: "lost"}, ""},
+		{WithdrawRequest{Coin: "BTC", Network: "BTC", Address: address, Amount: "0.5", WithdrawOrderID: "lost"}, ""},
+		// The address can't be checked.
+		{WithdrawRequest{Coin: "BTC", Network: "BAD", Address: address, Amount: "0.5"}, ""},
+	}
+
+	for i, c := range cases {
+		id, err := client.Withdraw(&c.request)
+		if id != c.expected || (c.expected == "") != (err != nil) {
+			t.Errorf("%d: got %s %v, expected %s", i, id, err, c.expected)
+		}
+	}
+
+	if applied != 3 {
+		t.Errorf("%d withdrawals applied, expected 3", applied)
+	}
+
+	// Without an allowlist nothing can be withdrawn.
+	client, _ = NewClient(APIKey("key"), APISecret("secret"), BaseURL(server.URL))
+
+	_, err := client.Withdraw(&WithdrawRequest{Coin: "BTC", Network: "BTC", Address: address, Amount: "0.5"})
+	if err == nil || applied != 3 {
+		t.Errorf("withdrawal without allowlist returned %v", err)
+	}
+}
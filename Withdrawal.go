@@ -0,0 +1,116 @@
+package binance
+
+import (
+	"net/url"
+	"sort"
+)
+
+// Withdrawal describes a withdrawal from the account.
+type Withdrawal struct {
+	ID              string         `json:"id"`
+	Amount          Value          `json:"amount"`
+	TransactionFee  Value          `json:"transactionFee"`
+	Coin            string         `json:"coin"`    // FIXME: type
+	Network         string         `json:"network"` // FIXME: type
+	Status          WithdrawStatus `json:"status"`
+	Address         string         `json:"address"`
+	TxID            string         `json:"txId"`
+	ApplyTime       Time           `json:"applyTime"`
+	CompleteTime    Time           `json:"completeTime"`
+	TransferType    int            `json:"transferType"`
+	WithdrawOrderID string         `json:"withdrawOrderId"`
+	Info            string         `json:"info"`
+	ConfirmNo       int            `json:"confirmNo"`
+	WalletType      int            `json:"walletType"`
+	TxKey           string         `json:"txKey"`
+}
+
+// Withdrawals lists withdrawals of coin, or all coins if coin is empty.
+// Without options, withdrawals from the last 90 days are returned. You can
+// refine the query with StartTime(), EndTime(), Offset() and Limit().
+// StartTime() and EndTime() can't be more than 90 days apart, use
+// WithdrawHistory() for walking longer periods.
+func (c *Client) Withdrawals(coin string, options ...QueryFunc) ([]Withdrawal, error) {
+	params := []func(url.Values){newQuery(options).params()}
+
+	if coin != "" {
+		params = append(params, param("coin", coin))
+	}
+
+	var withdrawals []Withdrawal
+	err := c.signedCall(&withdrawals, "GET", "/sapi/v1/capital/withdraw/history", params...)
+	if err != nil {
+		return nil, err
+	}
+
+	return withdrawals, nil
+}
+
+// WithdrawIterator walks all withdrawals in a period. It's used like
+// OrderListIterator.
+type WithdrawIterator struct {
+	client     *Client
+	coin       string
+	pager      *windowPager
+	page       []Withdrawal
+	withdrawal Withdrawal
+	err        error
+}
+
+// WithdrawHistory returns an iterator walking all withdrawals of coin, or
+// all coins if coin is empty, from start until end. Withdrawals are
+// returned in the order they were applied for.
+func (c *Client) WithdrawHistory(coin string, start Time, end Time) *WithdrawIterator {
+	return &WithdrawIterator{
+		client: c,
+		coin:   coin,
+		pager:  newWindowPager(start, end, walletWindow, 1000),
+	}
+}
+
+// Next advances the iterator to the next withdrawal. It returns false when
+// there are no more withdrawals or an error occurred.
+func (it *WithdrawIterator) Next() bool {
+	for len(it.page) == 0 {
+		if it.err != nil || it.pager.done {
+			return false
+		}
+
+		// Binance returns the newest first, so we need the whole window
+		// before we can return them in order.
+		for windowDone := false; !windowDone; {
+			var page []Withdrawal
+
+			it.err = retryRateLimited(func() error {
+				var err error
+				page, err = it.client.Withdrawals(it.coin, it.pager.query()...)
+
+				return err
+			})
+			if it.err != nil {
+				return false
+			}
+
+			it.page = append(it.page, page...)
+			windowDone = it.pager.advance(len(page))
+		}
+
+		sort.SliceStable(it.page, func(i, j int) bool {
+			return it.page[i].ApplyTime.Before(it.page[j].ApplyTime.Time)
+		})
+	}
+
+	it.withdrawal, it.page = it.page[0], it.page[1:]
+
+	return true
+}
+
+// Withdrawal returns the current withdrawal.
+func (it *WithdrawIterator) Withdrawal() Withdrawal {
+	return it.withdrawal
+}
+
+// Err returns the error that stopped the iterator, if any.
+func (it *WithdrawIterator) Err() error {
+	return it.err
+}
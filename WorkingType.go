@@ -0,0 +1,45 @@
+package binance
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// WorkingType decides which price triggers a futures stop order.
+type WorkingType string
+
+// The different prices triggering stop orders.
+const (
+	WorkingTypeMarkPrice     WorkingType = "MARK_PRICE"
+	WorkingTypeContractPrice WorkingType = "CONTRACT_PRICE"
+
+	zeroWorkingType WorkingType = ""
+)
+
+// UnmarshalJSON implements json.Unmarshaler while making sure only enums
+// that we know about end up in a WorkingType variable.
+func (w *WorkingType) UnmarshalJSON(data []byte) error {
+	str := ""
+	err := json.Unmarshal(data, &str)
+	if err != nil {
+		return err
+	}
+
+	typ := WorkingType(str)
+
+	switch typ {
+	case
+		WorkingTypeMarkPrice,
+		WorkingTypeContractPrice:
+		*w = typ
+	default:
+		return fmt.Errorf("%s is not a valid working type", str)
+	}
+
+	return nil
+}
+
+// String implement Stringer.
+func (w WorkingType) String() string {
+	return string(w)
+}
@@ -0,0 +1,363 @@
+// Package execution slices large parent orders into smaller child orders
+// executed over time, following schedules like TWAP, VWAP and POV.
+package execution
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	binance "github.com/algoholdet/gobinance"
+)
+
+// Client is used for submitting child orders. *binance.Client implements
+// it.
+type Client interface {
+	SubmitOrder(order *binance.Order) error
+	OrderStatus(symbol binance.Symbol, clientOrderID string, id int) (*binance.Order, error)
+	CancelOrder(symbol binance.Symbol, clientOrderID string, id int) (*binance.Order, error)
+}
+
+// Progress describes how far an execution has come.
+type Progress struct {
+	Executed      float64
+	Remaining     float64
+	QuoteQuantity float64
+	AveragePrice  float64
+	Children      int
+	Done          bool
+}
+
+// Option is used for configuring an Execution.
+type Option func(*Execution)
+
+// Interval sets how often the execution will check the schedule. The
+// default is one second.
+func Interval(interval time.Duration) Option {
+	return func(e *Execution) {
+		e.interval = interval
+	}
+}
+
+// MaxSlice limits the quantity of each child order. Along with Resting() and
+// Immediate this makes a client-side iceberg order.
+func MaxSlice(quantity binance.Value) Option {
+	return func(e *Execution) {
+		e.maxSlice = quantity.Float64()
+	}
+}
+
+// Resting makes children rest in the order book as GTC limit orders until
+// filled, instead of being sent as IOC orders. Only one child is working at
+// a time. This requires a limit price.
+func Resting() Option {
+	return func(e *Execution) {
+		e.resting = true
+	}
+}
+
+// ReferencePrice sets a function returning the current price of the
+// symbol. For market parents it is used for checking the notional filters
+// of the children. Without it the average price of the fills so far is
+// used, and the first child is only checked against the lot size.
+func ReferencePrice(f func() (binance.Value, error)) Option {
+	return func(e *Execution) {
+		e.referencePrice = f
+	}
+}
+
+// OnProgress sets a function to call when a child order is filled, and when
+// the execution is done.
+func OnProgress(f func(Progress)) Option {
+	return func(e *Execution) {
+		e.onProgress = f
+	}
+}
+
+// Execution executes a parent order by sending child orders as decided by a
+// strategy. Children are limit IOC orders at the limit price of the parent,
+// or market orders if the parent has no limit price. Children are rounded
+// to the lot size of the symbol, and children too small for the filters are
+// postponed until the strategy wants more.
+type Execution struct {
+	client   Client
+	info     *binance.SymbolInfo
+	parent   Parent
+	strategy Strategy
+
+	interval       time.Duration
+	maxSlice       float64
+	resting        bool
+	referencePrice func() (binance.Value, error)
+	onProgress     func(Progress)
+
+	stop     chan struct{}
+	stopOnce sync.Once
+
+	mu       sync.Mutex
+	progress Progress
+
+	// working is the resting child, if any. Fills of the child already
+	// accounted for are kept in workingExecuted and workingQuote.
+	working         *binance.Order
+	workingExecuted float64
+	workingQuote    float64
+}
+
+// New returns an execution of parent using strategy. info must describe the
+// symbol of parent, and is used for applying the filters to the children.
+func New(client Client, info *binance.SymbolInfo, parent Parent, strategy Strategy, options ...Option) (*Execution, error) {
+	if parent.Side != binance.OrderSideBuy && parent.Side != binance.OrderSideSell {
+		return nil, errors.New("parent has no valid side")
+	}
+
+	if parent.Quantity.Float64() <= 0 {
+		return nil, errors.New("parent quantity must be positive")
+	}
+
+	if binance.Symbol(info.Symbol.UpperCase()) != binance.Symbol(parent.Symbol.UpperCase()) {
+		return nil, errors.New("symbol info does not match parent")
+	}
+
+	if _, twap := strategy.(*TWAP); twap && parent.Horizon <= 0 {
+		return nil, errors.New("TWAP requires a positive horizon")
+	}
+
+	e := &Execution{
+		client:   client,
+		info:     info,
+		parent:   parent,
+		strategy: strategy,
+		interval: time.Second,
+		stop:     make(chan struct{}),
+	}
+
+	for _, option := range options {
+		option(e)
+	}
+
+	if e.resting && parent.LimitPrice == "" {
+		return nil, errors.New("resting children require a limit price")
+	}
+
+	e.progress.Remaining = parent.Quantity.Float64()
+
+	return e, nil
+}
+
+// Progress returns the current progress.
+func (e *Execution) Progress() Progress {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.progress
+}
+
+// Stop will make Run() cancel the working child and return.
+func (e *Execution) Stop() {
+	e.stopOnce.Do(func() {
+		close(e.stop)
+	})
+}
+
+// Run will execute the parent order. It blocks until the parent is
+// executed, the horizon has passed, Stop() is called, or a child order
+// fails. Whatever is not executed is left in Progress().Remaining.
+func (e *Execution) Run() error {
+	start := time.Now()
+
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		now := time.Now()
+		final := e.parent.Horizon > 0 && !now.Before(start.Add(e.parent.Horizon))
+
+		done, err := e.step(start, now)
+		if err != nil {
+			_ = e.finish()
+			return err
+		}
+
+		if done || final {
+			return e.finish()
+		}
+
+		select {
+		case <-e.stop:
+			return e.finish()
+		case <-ticker.C:
+		}
+	}
+}
+
+// step will update the working child, and submit a new child if the
+// strategy wants more executed. It returns true when nothing more can be
+// executed.
+func (e *Execution) step(start time.Time, now time.Time) (bool, error) {
+	if e.working != nil {
+		order, err := e.client.OrderStatus(e.parent.Symbol, e.working.ClientOrderID, 0)
+		if err != nil {
+			return false, err
+		}
+
+		e.account(order)
+
+		if !order.Status.Final() {
+			return false, nil
+		}
+
+		e.working = nil
+	}
+
+	total := e.parent.Quantity.Float64()
+	executed := e.Progress().Executed
+	market := e.parent.LimitPrice == ""
+
+	price, err := e.price()
+	if err != nil {
+		return false, err
+	}
+
+	// If the remainder is too small for the filters, we're done.
+	remaining := e.info.RoundQuantity(total-executed, market)
+	if remaining.Float64() <= 0 || e.info.CheckOrder(remaining, price, market) != nil {
+		return true, nil
+	}
+
+	target := e.strategy.Target(&e.parent, start, now)
+	if target > total {
+		target = total
+	}
+
+	want := target - executed
+	if e.maxSlice > 0 && want > e.maxSlice {
+		want = e.maxSlice
+	}
+
+	if max := e.info.MaxQuantity(market).Float64(); max > 0 && want > max {
+		want = max
+	}
+
+	quantity := e.info.RoundQuantity(want, market)
+	if quantity.Float64() <= 0 || e.info.CheckOrder(quantity, price, market) != nil {
+		return false, nil
+	}
+
+	child, err := e.child(quantity)
+	if err != nil {
+		return false, err
+	}
+
+	err = e.client.SubmitOrder(child)
+	if err != nil {
+		return false, err
+	}
+
+	e.mu.Lock()
+	e.progress.Children++
+	e.mu.Unlock()
+
+	e.workingExecuted, e.workingQuote = 0, 0
+	e.account(child)
+
+	if !child.Status.Final() {
+		e.working = child
+	}
+
+	return false, nil
+}
+
+// price returns the price used for checking the filters of the children.
+// This is the limit price of the parent, or for market parents the
+// reference price or the average price of the fills so far. If no price is
+// known yet, an empty value is returned, and only the lot size is checked.
+func (e *Execution) price() (binance.Value, error) {
+	if e.parent.LimitPrice != "" {
+		return e.parent.LimitPrice, nil
+	}
+
+	if e.referencePrice != nil {
+		return e.referencePrice()
+	}
+
+	average := e.Progress().AveragePrice
+	if average <= 0 {
+		return "", nil
+	}
+
+	return e.info.RoundPrice(average), nil
+}
+
+// child returns a new child order for quantity.
+func (e *Execution) child(quantity binance.Value) (*binance.Order, error) {
+	p := &e.parent
+
+	if p.LimitPrice == "" {
+		return binance.MarketOrder(p.Symbol, p.Side, quantity)
+	}
+
+	timeInForce := binance.IOC
+	if e.resting {
+		timeInForce = binance.GTC
+	}
+
+	return binance.LimitOrder(p.Symbol, p.Side, quantity, p.LimitPrice, binance.WithTimeInForce(timeInForce))
+}
+
+// account will add the fills of the current child not yet accounted for.
+func (e *Execution) account(child *binance.Order) {
+	executed := child.ExecutedQuantity.Float64()
+	quote := child.CummulativeQuoteQuantity.Float64()
+
+	if executed <= e.workingExecuted {
+		return
+	}
+
+	e.mu.Lock()
+	e.progress.Executed += executed - e.workingExecuted
+	e.progress.QuoteQuantity += quote - e.workingQuote
+	e.progress.Remaining = e.parent.Quantity.Float64() - e.progress.Executed
+	e.progress.AveragePrice = e.progress.QuoteQuantity / e.progress.Executed
+	progress := e.progress
+	e.mu.Unlock()
+
+	e.workingExecuted, e.workingQuote = executed, quote
+
+	if e.onProgress != nil {
+		e.onProgress(progress)
+	}
+}
+
+// finish will cancel the working child, if any, and mark the execution as
+// done.
+func (e *Execution) finish() error {
+	var err error
+
+	if e.working != nil {
+		var order *binance.Order
+
+		order, err = e.client.CancelOrder(e.parent.Symbol, e.working.ClientOrderID, 0)
+		if err != nil {
+			// The child could have been filled in the meantime.
+			order, err = e.client.OrderStatus(e.parent.Symbol, e.working.ClientOrderID, 0)
+		}
+
+		if err == nil {
+			e.account(order)
+		}
+
+		e.working = nil
+	}
+
+	e.mu.Lock()
+	e.progress.Done = true
+	progress := e.progress
+	e.mu.Unlock()
+
+	if e.onProgress != nil {
+		e.onProgress(progress)
+	}
+
+	return err
+}
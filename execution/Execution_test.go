@@ -0,0 +1,269 @@
+package execution
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	binance "github.com/algoholdet/gobinance"
+)
+
+// paperClient returns a client trading BTCUSDT on a paper account.
+func paperClient(t *testing.T) (*binance.Client, *binance.PaperAccount, *binance.SymbolInfo) {
+	info := &binance.SymbolInfo{
+		Symbol:     "BTCUSDT",
+		BaseAsset:  "BTC",
+		QuoteAsset: "USDT",
+		Filters: []binance.Filter{
+			{Type: binance.FilterLotSize, MinQuantity: "0.001", MaxQuantity: "100", StepSize: "0.001"},
+			{Type: binance.FilterNotional, MinNotional: "5"},
+		},
+	}
+
+	account := binance.NewPaperAccount(
+		binance.PaperSymbols(*info),
+		binance.PaperBalance("USDT", "10000"),
+	)
+
+	client, err := binance.NewClient(binance.BaseURL("http://localhost:1"), binance.PaperTrading(account))
+	if err != nil {
+		t.Fatalf("NewClient failed: %s", err.Error())
+	}
+
+	return client, account, info
+}
+
+func TestExecutionTWAP(t *testing.T) {
+	client, account, info := paperClient(t)
+	account.Tick("BTCUSDT", "100", binance.Time{})
+
+	parent := Parent{
+		Symbol:   "BTCUSDT",
+		Side:     binance.OrderSideBuy,
+		Quantity: "10",
+		Horizon:  100 * time.Millisecond,
+	}
+
+	updates := 0
+	e, err := New(client, info, parent, NewTWAP(5, 0), Interval(5*time.Millisecond), OnProgress(func(Progress) { updates++ }))
+	if err != nil {
+		t.Fatalf("New failed: %s", err.Error())
+	}
+
+	err = e.Run()
+	if err != nil {
+		t.Fatalf("Run failed: %s", err.Error())
+	}
+
+	p := e.Progress()
+	if !p.Done || p.Executed != 10 || p.Remaining != 0 || p.Children != 5 || p.AveragePrice != 100 {
+		t.Errorf("got progress %+v", p)
+	}
+
+	// One update per child and one when done.
+	if updates != 6 {
+		t.Errorf("got %d progress updates", updates)
+	}
+
+	parent.Horizon = 0
+	_, err = New(client, info, parent, NewTWAP(5, 0))
+	if err == nil {
+		t.Errorf("New accepted TWAP without a horizon")
+	}
+}
+
+func TestExecutionReferencePrice(t *testing.T) {
+	client, account, info := paperClient(t)
+	account.Tick("BTCUSDT", "100", binance.Time{})
+
+	// A notional of 4 is below the minimum of 5, so no child is sent.
+	parent := Parent{
+		Symbol:   "BTCUSDT",
+		Side:     binance.OrderSideBuy,
+		Quantity: "0.04",
+	}
+
+	price := func() (binance.Value, error) { return "100", nil }
+
+	e, err := New(client, info, parent, Immediate{}, ReferencePrice(price), Interval(time.Millisecond))
+	if err != nil {
+		t.Fatalf("New failed: %s", err.Error())
+	}
+
+	err = e.Run()
+	if err != nil {
+		t.Fatalf("Run failed: %s", err.Error())
+	}
+
+	if p := e.Progress(); !p.Done || p.Children != 0 || p.Remaining != 0.04 {
+		t.Errorf("got progress %+v", p)
+	}
+}
+
+func TestExecutionIceberg(t *testing.T) {
+	client, account, info := paperClient(t)
+	account.Tick("BTCUSDT", "100", binance.Time{})
+
+	parent := Parent{
+		Symbol:     "BTCUSDT",
+		Side:       binance.OrderSideBuy,
+		Quantity:   "3",
+		LimitPrice: "90",
+	}
+
+	e, err := New(client, info, parent, Immediate{}, MaxSlice("1"), Resting(), Interval(time.Millisecond))
+	if err != nil {
+		t.Fatalf("New failed: %s", err.Error())
+	}
+
+	done := make(chan error)
+	go func() { done <- e.Run() }()
+
+	// Wait for the first child to rest in the book, only a single child
+	// should be shown.
+	for e.Progress().Children == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	open, _ := client.OpenOrders("BTCUSDT")
+	if len(open) != 1 || open[0].Quantity.Float64() != 1 {
+		t.Errorf("got open orders %+v", open)
+	}
+
+	// Fills the resting child, the next children are placed at a known
+	// price of 89 and will fill right away.
+	account.Tick("BTCUSDT", "89", binance.Time{})
+
+	err = <-done
+	if err != nil {
+		t.Fatalf("Run failed: %s", err.Error())
+	}
+
+	p := e.Progress()
+	if p.Executed != 3 || p.Children != 3 || math.Abs(p.AveragePrice-(90+89+89)/3.0) > 1e-9 {
+		t.Errorf("got progress %+v", p)
+	}
+}
+
+func TestExecutionStop(t *testing.T) {
+	client, account, info := paperClient(t)
+	account.Tick("BTCUSDT", "100", binance.Time{})
+
+	parent := Parent{
+		Symbol:     "BTCUSDT",
+		Side:       binance.OrderSideBuy,
+		Quantity:   "1",
+		LimitPrice: "90",
+	}
+
+	e, _ := New(client, info, parent, Immediate{}, Resting(), Interval(time.Millisecond))
+
+	done := make(chan error)
+	go func() { done <- e.Run() }()
+
+	for e.Progress().Children == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	e.Stop()
+
+	err := <-done
+	if err != nil {
+		t.Fatalf("Run failed: %s", err.Error())
+	}
+
+	open, _ := client.OpenOrders("BTCUSDT")
+	if len(open) != 0 {
+		t.Errorf("working child not canceled: %+v", open)
+	}
+
+	if p := e.Progress(); !p.Done || p.Remaining != 1 {
+		t.Errorf("got progress %+v", p)
+	}
+}
+
+func TestTWAPTarget(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	parent := &Parent{Quantity: "10", Horizon: 10 * time.Minute}
+
+	cases := []struct {
+		elapsed  time.Duration
+		expected float64
+	}{
+		{0, 2},
+		{time.Minute, 2},
+		{2 * time.Minute, 4},
+		{9 * time.Minute, 10},
+		{time.Hour, 10},
+	}
+
+	twap := NewTWAP(5, 0)
+	for _, c := range cases {
+		got := twap.Target(parent, start, start.Add(c.elapsed))
+		if got != c.expected {
+			t.Errorf("target after %s was %f, expected %f", c.elapsed, got, c.expected)
+		}
+	}
+
+	// With jitter, slices must stay within the horizon and in order.
+	jittered := NewTWAP(10, 0.5)
+	jittered.Target(parent, start, start)
+
+	for i, due := range jittered.due {
+		if due < 0 || due > parent.Horizon || (i > 0 && due < jittered.due[i-1]) {
+			t.Errorf("bad schedule: %v", jittered.due)
+		}
+	}
+}
+
+// candles serves the same volume every day, one candle stick per minute
+// starting at the start time.
+type candles []string
+
+func (c candles) CandleStick(symbol binance.Symbol, interval string, options ...binance.QueryFunc) ([]binance.CandleStick, error) {
+	if interval != "1m" {
+		return nil, fmt.Errorf("unexpected interval %s", interval)
+	}
+
+	sticks := make([]binance.CandleStick, len(c))
+	for i, volume := range c {
+		sticks[i] = binance.CandleStick{
+			OpenTime: binance.FromTime(vwapStart.Add(-24*time.Hour + time.Duration(i)*time.Minute)),
+			Volume:   binance.Value(volume),
+		}
+	}
+
+	return sticks, nil
+}
+
+var vwapStart = time.Date(2020, 1, 2, 12, 0, 0, 0, time.UTC)
+
+func TestVWAPTarget(t *testing.T) {
+	v, err := NewVWAP(candles{"1", "3", "0", "4"}, "BTCUSDT", vwapStart, 4*time.Minute, 1)
+	if err != nil {
+		t.Fatalf("NewVWAP failed: %s", err.Error())
+	}
+
+	parent := &Parent{Quantity: "16"}
+
+	cases := []struct {
+		elapsed  time.Duration
+		expected float64
+	}{
+		{0, 0},
+		{30 * time.Second, 1},
+		{time.Minute, 2},
+		{2 * time.Minute, 8},
+		{150 * time.Second, 8},
+		{210 * time.Second, 12},
+		{time.Hour, 16},
+	}
+
+	for _, c := range cases {
+		got := v.Target(parent, vwapStart, vwapStart.Add(c.elapsed))
+		if math.Abs(got-c.expected) > 1e-9 {
+			t.Errorf("target after %s was %f, expected %f", c.elapsed, got, c.expected)
+		}
+	}
+}
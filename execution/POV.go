@@ -0,0 +1,54 @@
+package execution
+
+import (
+	"sync"
+	"time"
+
+	binance "github.com/algoholdet/gobinance"
+)
+
+// POV executes a fixed share of the market volume. The market volume must be
+// fed using Trade() or FollowTrades(), and is counted from the first trade
+// fed. Our own fills are part of the market volume.
+type POV struct {
+	rate float64
+
+	mu     sync.Mutex
+	volume float64
+}
+
+// NewPOV returns a POV strategy targeting rate of the market volume. 0.1
+// means 10%.
+func NewPOV(rate float64) *POV {
+	return &POV{
+		rate: rate,
+	}
+}
+
+// Trade will add trade to the market volume.
+func (p *POV) Trade(trade *binance.Trade) {
+	p.mu.Lock()
+	p.volume += trade.Quantity.Float64()
+	p.mu.Unlock()
+}
+
+// FollowTrades will feed trades from stream to Trade() until reading from
+// the stream fails.
+func (p *POV) FollowTrades(stream *binance.TradeStream) error {
+	for {
+		trade, err := stream.Read()
+		if err != nil {
+			return err
+		}
+
+		p.Trade(trade)
+	}
+}
+
+// Target implements Strategy.
+func (p *POV) Target(parent *Parent, start time.Time, now time.Time) float64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.volume * p.rate
+}
@@ -0,0 +1,23 @@
+package execution
+
+import (
+	"time"
+
+	binance "github.com/algoholdet/gobinance"
+)
+
+// Parent is an order to be executed in slices.
+type Parent struct {
+	Symbol   binance.Symbol
+	Side     binance.OrderSide
+	Quantity binance.Value
+
+	// LimitPrice is the worst price accepted. If empty, children are sent
+	// as market orders.
+	LimitPrice binance.Value
+
+	// Horizon is the time allowed for the execution. When it has passed,
+	// the execution stops, even if the parent is not completely executed.
+	// Zero means no deadline.
+	Horizon time.Duration
+}
@@ -0,0 +1,22 @@
+package execution
+
+import (
+	"time"
+)
+
+// Strategy decides how much of a parent order should be executed at a
+// given time.
+type Strategy interface {
+	// Target returns the quantity of parent that should be executed at now,
+	// for an execution started at start.
+	Target(parent *Parent, start time.Time, now time.Time) float64
+}
+
+// Immediate is a strategy wanting the whole parent executed right away. It's
+// useful along with MaxSlice() for iceberg orders.
+type Immediate struct{}
+
+// Target implements Strategy.
+func (Immediate) Target(parent *Parent, start time.Time, now time.Time) float64 {
+	return parent.Quantity.Float64()
+}
@@ -0,0 +1,75 @@
+package execution
+
+import (
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// TWAP executes the parent in equal slices evenly spaced over the horizon.
+// The first slice is due right away. Each of the following slices is moved
+// randomly by up to jitter times the slice interval, to make the execution
+// harder to spot. The parent must have a horizon.
+type TWAP struct {
+	slices int
+	jitter float64
+	rand   *rand.Rand
+
+	// due is when each slice is due, relative to the start.
+	due []time.Duration
+}
+
+// NewTWAP returns a TWAP strategy executing in slices. jitter should be
+// between 0 and 0.5.
+func NewTWAP(slices int, jitter float64) *TWAP {
+	if slices < 1 {
+		slices = 1
+	}
+
+	return &TWAP{
+		slices: slices,
+		jitter: jitter,
+		rand:   rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// schedule will decide when each slice is due.
+func (t *TWAP) schedule(horizon time.Duration) {
+	interval := horizon / time.Duration(t.slices)
+
+	t.due = make([]time.Duration, t.slices)
+	for i := 1; i < t.slices; i++ {
+		offset := time.Duration((t.rand.Float64()*2 - 1) * t.jitter * float64(interval))
+
+		due := time.Duration(i)*interval + offset
+		if due < 0 {
+			due = 0
+		}
+
+		if due > horizon {
+			due = horizon
+		}
+
+		t.due[i] = due
+	}
+
+	sort.Slice(t.due, func(i, j int) bool { return t.due[i] < t.due[j] })
+}
+
+// Target implements Strategy.
+func (t *TWAP) Target(parent *Parent, start time.Time, now time.Time) float64 {
+	if t.due == nil {
+		t.schedule(parent.Horizon)
+	}
+
+	elapsed := now.Sub(start)
+
+	n := 0
+	for _, due := range t.due {
+		if due <= elapsed {
+			n++
+		}
+	}
+
+	return parent.Quantity.Float64() * float64(n) / float64(t.slices)
+}
@@ -0,0 +1,137 @@
+package execution
+
+import (
+	"errors"
+	"time"
+
+	binance "github.com/algoholdet/gobinance"
+)
+
+// CandleSource is used for fetching historical volume. *binance.Client
+// implements it.
+type CandleSource interface {
+	CandleStick(symbol binance.Symbol, interval string, options ...binance.QueryFunc) ([]binance.CandleStick, error)
+}
+
+// vwapIntervals are the candle stick intervals considered for the volume
+// curve, finest first.
+var vwapIntervals = []struct {
+	name     string
+	duration time.Duration
+}{
+	{"1m", time.Minute},
+	{"5m", 5 * time.Minute},
+	{"15m", 15 * time.Minute},
+	{"1h", time.Hour},
+}
+
+// VWAP executes following the volume curve seen at the same time of day on
+// previous days.
+type VWAP struct {
+	horizon time.Duration
+	bucket  time.Duration
+
+	// curve is the share of the volume traded at the end of each bucket.
+	curve []float64
+}
+
+// NewVWAP returns a VWAP strategy for an execution of symbol starting at
+// start and lasting horizon. The volume curve is the average of the volume
+// from the same period on the previous days. horizon can't be more than 24
+// hours.
+func NewVWAP(source CandleSource, symbol binance.Symbol, start time.Time, horizon time.Duration, days int) (*VWAP, error) {
+	if horizon <= 0 || horizon > 24*time.Hour {
+		return nil, errors.New("horizon must be between zero and 24 hours")
+	}
+
+	if days < 1 {
+		return nil, errors.New("at least one day of history is needed")
+	}
+
+	// We use the finest interval not needing more than one page of candle
+	// sticks per day.
+	interval := vwapIntervals[len(vwapIntervals)-1]
+	for _, i := range vwapIntervals {
+		if horizon/i.duration <= 1000 {
+			interval = i
+			break
+		}
+	}
+
+	buckets := int((horizon + interval.duration - 1) / interval.duration)
+	volume := make([]float64, buckets)
+
+	for day := 1; day <= days; day++ {
+		from := start.Add(-time.Duration(day) * 24 * time.Hour).Truncate(interval.duration)
+
+		sticks, err := source.CandleStick(symbol, interval.name,
+			binance.StartTime(binance.FromTime(from)),
+			binance.EndTime(binance.FromTime(from.Add(horizon-time.Millisecond))),
+			binance.Limit(1000),
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, stick := range sticks {
+			i := int(stick.OpenTime.Sub(from) / interval.duration)
+			if i >= 0 && i < buckets {
+				volume[i] += stick.Volume.Float64()
+			}
+		}
+	}
+
+	return newVWAP(horizon, interval.duration, volume), nil
+}
+
+// newVWAP returns a VWAP strategy from the volume of each bucket. If there's
+// no volume at all, the volume is assumed to be evenly spread.
+func newVWAP(horizon time.Duration, bucket time.Duration, volume []float64) *VWAP {
+	total := 0.0
+	for _, v := range volume {
+		total += v
+	}
+
+	curve := make([]float64, len(volume))
+	sum := 0.0
+
+	for i, v := range volume {
+		if total > 0 {
+			sum += v / total
+		} else {
+			sum += 1 / float64(len(volume))
+		}
+
+		curve[i] = sum
+	}
+
+	return &VWAP{
+		horizon: horizon,
+		bucket:  bucket,
+		curve:   curve,
+	}
+}
+
+// Target implements Strategy. The curve is interpolated within each bucket.
+func (v *VWAP) Target(parent *Parent, start time.Time, now time.Time) float64 {
+	total := parent.Quantity.Float64()
+
+	elapsed := now.Sub(start)
+	if elapsed < 0 {
+		return 0
+	}
+
+	i := int(elapsed / v.bucket)
+	if elapsed >= v.horizon || i >= len(v.curve) {
+		return total
+	}
+
+	previous := 0.0
+	if i > 0 {
+		previous = v.curve[i-1]
+	}
+
+	within := float64(elapsed%v.bucket) / float64(v.bucket)
+
+	return total * (previous + (v.curve[i]-previous)*within)
+}
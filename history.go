@@ -0,0 +1,221 @@
+package binance
+
+import (
+	"time"
+)
+
+// historyWindow is the longest time span Binance allows between startTime
+// and endTime for the history endpoints.
+const historyWindow = 24 * time.Hour
+
+// historyPager is used for walking the history endpoints. Binance will not
+// answer queries spanning more than 24 hours, and will only return a limited
+// number of objects per call. We start by walking 24 hour windows until we
+// find the first object, and continue by ID from there, as queries by ID
+// are not limited in time.
+type historyPager struct {
+	start Time
+	end   Time
+	limit int
+
+	// fromID is the ID to continue from, or zero if we're still searching
+	// by time.
+	fromID int64
+	done   bool
+}
+
+// newHistoryPager returns a pager walking from start to end, both
+// inclusive. limit is the number of objects to request per page.
+func newHistoryPager(start Time, end Time, limit int) *historyPager {
+	return &historyPager{
+		start: start,
+		end:   end,
+		limit: limit,
+		done:  end.Before(start.Time),
+	}
+}
+
+// query returns the options for fetching the next page. id is used to
+// construct the option for continuing from an ID, as the endpoints disagree
+// on the name.
+func (p *historyPager) query(id func(int64) QueryFunc) []QueryFunc {
+	if p.fromID != 0 {
+		return []QueryFunc{id(p.fromID), Limit(p.limit)}
+	}
+
+	windowEnd := FromTime(p.start.Add(historyWindow - time.Millisecond))
+	if windowEnd.After(p.end.Time) {
+		windowEnd = p.end
+	}
+
+	return []QueryFunc{StartTime(p.start), EndTime(windowEnd), Limit(p.limit)}
+}
+
+// advance will move the pager past a page of n objects. lastID and lastTime
+// describe the last object in the page. The caller must still skip objects
+// past the end of the walk.
+func (p *historyPager) advance(n int, lastID int64, lastTime Time) {
+	byID := p.fromID != 0
+
+	switch {
+	case n == 0 && byID:
+		p.done = true
+
+	case n == 0:
+		p.start = FromTime(p.start.Add(historyWindow))
+		p.done = p.start.After(p.end.Time)
+
+	default:
+		p.fromID = lastID + 1
+
+		// A page by ID that's not full means there's nothing more to find.
+		p.done = lastTime.After(p.end.Time) || (byID && n < p.limit)
+	}
+}
+
+// historyPage is a page of objects from one of the history endpoints.
+type historyPage interface {
+	Len() int
+	ID(i int) int64
+	Time(i int) Time
+}
+
+// historyWalker walks one of the history endpoints using a historyPager. It
+// does the work for the history iterators.
+type historyWalker struct {
+	pager *historyPager
+
+	// id constructs the option for continuing from an ID, as the endpoints
+	// disagree on the name.
+	id func(int64) QueryFunc
+
+	// fetch returns the page for options.
+	fetch func(options ...QueryFunc) (historyPage, error)
+
+	page historyPage
+	next int
+	err  error
+}
+
+// newHistoryWalker returns a walker fetching 1000 objects per page from
+// start until end, both inclusive.
+func newHistoryWalker(start Time, end Time, id func(int64) QueryFunc, fetch func(options ...QueryFunc) (historyPage, error)) *historyWalker {
+	return &historyWalker{
+		pager: newHistoryPager(start, end, 1000),
+		id:    id,
+		fetch: fetch,
+	}
+}
+
+// advance moves to the next object, and returns the page and the index of
+// it. It returns false when there are no more objects or an error
+// occurred.
+func (w *historyWalker) advance() (historyPage, int, bool) {
+	for w.page == nil || w.next >= w.page.Len() {
+		if w.err != nil || w.pager.done {
+			return nil, 0, false
+		}
+
+		var page historyPage
+		w.err = retryRateLimited(func() error {
+			var err error
+			page, err = w.fetch(w.pager.query(w.id)...)
+
+			return err
+		})
+		if w.err != nil {
+			return nil, 0, false
+		}
+
+		w.page, w.next = page, 0
+
+		n := page.Len()
+		if n == 0 {
+			w.pager.advance(0, 0, Time{})
+			continue
+		}
+
+		w.pager.advance(n, page.ID(n-1), page.Time(n-1))
+	}
+
+	i := w.next
+	w.next++
+
+	if w.page.Time(i).After(w.pager.end.Time) {
+		w.page = nil
+		w.pager.done = true
+
+		return nil, 0, false
+	}
+
+	return w.page, i, true
+}
+
+// walletWindow is the longest time span Binance allows between startTime
+// and endTime for the wallet history endpoints.
+const walletWindow = 90 * 24 * time.Hour
+
+// windowPager is used for walking the wallet history endpoints. They don't
+// support continuing from an ID, so we walk windows of time, and page by
+// offset within each window.
+type windowPager struct {
+	start  Time
+	end    Time
+	window time.Duration
+	limit  int
+	offset int
+	done   bool
+}
+
+// newWindowPager returns a pager walking from start to end, both inclusive,
+// in windows of window. limit is the number of objects to request per page.
+func newWindowPager(start Time, end Time, window time.Duration, limit int) *windowPager {
+	return &windowPager{
+		start:  start,
+		end:    end,
+		window: window,
+		limit:  limit,
+		done:   end.Before(start.Time),
+	}
+}
+
+// query returns the options for fetching the next page.
+func (p *windowPager) query() []QueryFunc {
+	windowEnd := FromTime(p.start.Add(p.window - time.Millisecond))
+	if windowEnd.After(p.end.Time) {
+		windowEnd = p.end
+	}
+
+	return []QueryFunc{StartTime(p.start), EndTime(windowEnd), Limit(p.limit), Offset(p.offset)}
+}
+
+// advance will move the pager past a page of n objects. It returns true when
+// the current window is done.
+func (p *windowPager) advance(n int) bool {
+	if n >= p.limit {
+		p.offset += n
+		return false
+	}
+
+	p.offset = 0
+	p.start = FromTime(p.start.Add(p.window))
+	p.done = p.start.After(p.end.Time)
+
+	return true
+}
+
+// historyRetries is how many times a page is retried when rate limited.
+const historyRetries = 3
+
+// retryRateLimited will call f until it succeeds, fails for reasons other
+// than rate limiting, or historyRetries is exhausted. The client will wait as
+// instructed by Binance before each retry.
+func retryRateLimited(f func() error) error {
+	err := f()
+
+	for i := 0; i < historyRetries && rateLimited(err); i++ {
+		err = f()
+	}
+
+	return err
+}
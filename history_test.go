@@ -0,0 +1,205 @@
+package binance
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// historyServer serves n order lists, orders or trades, one every interval
+// starting at start. It enforces the same rules as Binance.
+func historyServer(t *testing.T, start time.Time, interval time.Duration, n int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The key used for continuing from an ID, and the keys of the ID
+		// and the time of each object.
+		fromKey, idKey, timeKey := "fromId", "orderListId", "transactionTime"
+
+		switch r.URL.Path {
+		case "/api/v3/allOrders":
+			fromKey, idKey, timeKey = "orderId", "orderId", "time"
+		case "/api/v3/myTrades":
+			idKey, timeKey = "id", "time"
+		}
+
+		q := r.URL.Query()
+		limit, _ := strconv.Atoi(q.Get("limit"))
+
+		fromID, _ := strconv.ParseInt(q.Get(fromKey), 10, 64)
+		startTime, _ := strconv.ParseInt(q.Get("startTime"), 10, 64)
+		endTime, _ := strconv.ParseInt(q.Get("endTime"), 10, 64)
+
+		if fromID != 0 && (startTime != 0 || endTime != 0) {
+			t.Errorf("%s used along with time", fromKey)
+		}
+
+		if endTime-startTime > int64(historyWindow/time.Millisecond) {
+			t.Errorf("time window too long: %s", time.Duration(endTime-startTime)*time.Millisecond)
+		}
+
+		objects := []map[string]interface{}{}
+		for i := 1; i <= n && len(objects) < limit; i++ {
+			ts := start.Add(time.Duration(i)*interval).UnixNano() / int64(time.Millisecond)
+
+			if fromID != 0 && int64(i) < fromID {
+				continue
+			}
+
+			if fromID == 0 && (ts < startTime || ts > endTime) {
+				continue
+			}
+
+			objects = append(objects, map[string]interface{}{
+				idKey:             i,
+				timeKey:           ts,
+				"symbol":          "BTCUSDT",
+				"contingencyType": "OCO",
+			})
+		}
+
+		_ = json.NewEncoder(w).Encode(objects)
+	}))
+}
+
+// historyCases are walked by all the history iterators.
+var historyCases = []struct {
+	interval time.Duration
+	n        int
+	from     time.Duration
+	to       time.Duration
+	first    int64
+	last     int64
+}{
+	// Sparse objects, we should walk windows until we find the first.
+	{72 * time.Hour, 10, 0, 30 * 24 * time.Hour, 1, 10},
+	// Dense objects, more than a page in a window.
+	{time.Minute, 2500, 0, 24 * time.Hour, 1, 1440},
+	// Starting in the middle and continuing by ID across full pages.
+	{time.Minute, 2500, 100 * time.Minute, 2000 * time.Minute, 100, 2000},
+	// Nothing in the period.
+	{time.Minute, 10, time.Hour, 48 * time.Hour, 0, 0},
+}
+
+// walkHistory runs the history cases, using walk to walk the period from
+// start to end. walk must return the IDs seen.
+func walkHistory(t *testing.T, walk func(client *Client, start Time, end Time) ([]int64, error)) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for i, c := range historyCases {
+		server := historyServer(t, start, c.interval, c.n)
+		client, _ := NewClient(APIKey("key"), APISecret("secret"), BaseURL(server.URL))
+
+		ids, err := walk(client, FromTime(start.Add(c.from)), FromTime(start.Add(c.to)))
+
+		server.Close()
+
+		if err != nil {
+			t.Errorf("case %d: iterator failed: %s", i, err.Error())
+		}
+
+		var first, last int64
+		for _, id := range ids {
+			if last != 0 && id != last+1 {
+				t.Errorf("case %d: got %d after %d", i, id, last)
+			}
+
+			if first == 0 {
+				first = id
+			}
+
+			last = id
+		}
+
+		if first != c.first || last != c.last {
+			t.Errorf("case %d: got %d to %d, expected %d to %d", i, first, last, c.first, c.last)
+		}
+	}
+}
+
+func TestOrderListHistory(t *testing.T) {
+	walkHistory(t, func(client *Client, start Time, end Time) ([]int64, error) {
+		var ids []int64
+
+		it := client.OrderListHistory(start, end)
+		for it.Next() {
+			ids = append(ids, int64(it.OrderList().ID))
+		}
+
+		return ids, it.Err()
+	})
+}
+
+func TestOrderHistory(t *testing.T) {
+	walkHistory(t, func(client *Client, start Time, end Time) ([]int64, error) {
+		var ids []int64
+
+		it := client.OrderHistory("BTCUSDT", start, end)
+		for it.Next() {
+			ids = append(ids, int64(it.Order().ID))
+		}
+
+		return ids, it.Err()
+	})
+}
+
+func TestTradeHistory(t *testing.T) {
+	walkHistory(t, func(client *Client, start Time, end Time) ([]int64, error) {
+		var ids []int64
+
+		it := client.TradeHistory("BTCUSDT", start, end)
+		for it.Next() {
+			ids = append(ids, it.Trade().ID)
+		}
+
+		return ids, it.Err()
+	})
+}
+
+func TestOrderListHistoryRateLimited(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	backend := historyServer(t, start, time.Hour, 5)
+	defer backend.Close()
+
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+
+		if calls%2 == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		r.URL.Scheme = "http"
+		r.URL.Host = backend.Listener.Addr().String()
+
+		response, err := http.Get(r.URL.String())
+		if err != nil {
+			t.Errorf("backend failed: %s", err.Error())
+			return
+		}
+		defer response.Body.Close()
+
+		_, _ = io.Copy(w, response.Body)
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(APIKey("key"), APISecret("secret"), BaseURL(server.URL))
+
+	count := 0
+	it := client.OrderListHistory(FromTime(start), FromTime(start.Add(24*time.Hour)))
+	for it.Next() {
+		count++
+	}
+
+	if it.Err() != nil {
+		t.Errorf("iterator failed: %s", it.Err().Error())
+	}
+
+	if count != 5 {
+		t.Errorf("got %d lists, expected 5", count)
+	}
+}
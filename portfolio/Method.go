@@ -0,0 +1,43 @@
+package portfolio
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Method decides which lots are disposed of first when selling, and thereby
+// the cost basis of what is sold.
+type Method string
+
+// The different cost basis methods.
+const (
+	FIFO        Method = "FIFO"
+	LIFO        Method = "LIFO"
+	AverageCost Method = "AVERAGE_COST"
+)
+
+// UnmarshalJSON implements json.Unmarshaler while making sure only enums
+// that we know about end up in a Method variable.
+func (m *Method) UnmarshalJSON(data []byte) error {
+	s := ""
+	err := json.Unmarshal(data, &s)
+	if err != nil {
+		return err
+	}
+
+	method := Method(s)
+
+	switch method {
+	case FIFO, LIFO, AverageCost:
+		*m = method
+	default:
+		return fmt.Errorf("%s is not a valid cost basis method", s)
+	}
+
+	return nil
+}
+
+// String implement Stringer.
+func (m Method) String() string {
+	return string(m)
+}
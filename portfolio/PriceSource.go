@@ -0,0 +1,66 @@
+package portfolio
+
+import (
+	"fmt"
+	"time"
+
+	binance "github.com/algoholdet/gobinance"
+)
+
+// PriceSource returns the price of an asset in the quote asset of the
+// tracker.
+type PriceSource interface {
+	Price(asset string, at time.Time) (float64, error)
+}
+
+// LivePrices is a PriceSource using the latest prices from Binance. The time
+// asked for is ignored, so it's only useful for valuing current positions
+// and live trades.
+type LivePrices struct {
+	Client *binance.Client
+	Quote  string
+}
+
+// Price implements PriceSource.
+func (p *LivePrices) Price(asset string, at time.Time) (float64, error) {
+	if asset == p.Quote {
+		return 1, nil
+	}
+
+	price, err := p.Client.LatestPrice(binance.Symbol(asset + p.Quote))
+	if err != nil {
+		return 0, err
+	}
+
+	return price.Float64(), nil
+}
+
+// HistoricalPrices is a PriceSource using the closing price of the minute
+// candle stick at the time asked for.
+type HistoricalPrices struct {
+	Client *binance.Client
+	Quote  string
+}
+
+// Price implements PriceSource.
+func (p *HistoricalPrices) Price(asset string, at time.Time) (float64, error) {
+	if asset == p.Quote {
+		return 1, nil
+	}
+
+	symbol := binance.Symbol(asset + p.Quote)
+
+	sticks, err := p.Client.CandleStick(symbol, "1m",
+		binance.StartTime(binance.FromTime(at.Truncate(time.Minute))),
+		binance.Limit(1),
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(sticks) == 0 {
+		return 0, fmt.Errorf("no price for %s at %s", symbol, at)
+	}
+
+	return sticks[0].Close.Float64(), nil
+}
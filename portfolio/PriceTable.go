@@ -0,0 +1,168 @@
+package portfolio
+
+import (
+	"fmt"
+	"time"
+
+	binance "github.com/algoholdet/gobinance"
+)
+
+// DefaultBridges are the assets used for converting between assets without
+// a direct pair.
+var DefaultBridges = []string{"BTC", "USDT", "BNB"}
+
+// quote is the bid and ask of a symbol.
+type quote struct {
+	bid float64
+	ask float64
+}
+
+// PriceTable is a snapshot of the prices of all symbols, used for converting
+// between any two assets. Assets without a direct pair are converted through
+// a bridge asset.
+type PriceTable struct {
+	priceType PriceType
+	bridges   []string
+	quotes    map[binance.Symbol]quote
+}
+
+// NewPriceTable returns a snapshot of the current prices of all symbols.
+// LastPrice uses LatestPriceAll(), the other price types use
+// BestPriceAll(). If no bridges are given, DefaultBridges are used.
+func NewPriceTable(client *binance.Client, priceType PriceType, bridges ...string) (*PriceTable, error) {
+	quotes := make(map[binance.Symbol]quote)
+
+	switch priceType {
+	case LastPrice:
+		prices, err := client.LatestPriceAll()
+		if err != nil {
+			return nil, err
+		}
+
+		for symbol, price := range prices {
+			quotes[symbol] = quote{bid: price.Float64(), ask: price.Float64()}
+		}
+
+	case BidPrice, AskPrice, MidPrice:
+		prices, err := client.BestPriceAll()
+		if err != nil {
+			return nil, err
+		}
+
+		for symbol, price := range prices {
+			quotes[symbol] = quote{bid: price.Bid.Price.Float64(), ask: price.Ask.Price.Float64()}
+		}
+
+	default:
+		return nil, fmt.Errorf("%s is not a valid price type", priceType)
+	}
+
+	return newPriceTable(priceType, quotes, bridges), nil
+}
+
+// newPriceTable returns a price table using quotes.
+func newPriceTable(priceType PriceType, quotes map[binance.Symbol]quote, bridges []string) *PriceTable {
+	if len(bridges) == 0 {
+		bridges = DefaultBridges
+	}
+
+	return &PriceTable{
+		priceType: priceType,
+		bridges:   bridges,
+		quotes:    quotes,
+	}
+}
+
+// direct returns the price of one from in to, using the pair of the two
+// assets in either direction.
+func (t *PriceTable) direct(from string, to string) (float64, bool) {
+	if q, found := t.quotes[binance.Symbol(from+to)]; found {
+		var price float64
+
+		// Selling from at the bid, buying from at the ask.
+		switch t.priceType {
+		case BidPrice:
+			price = q.bid
+		case AskPrice:
+			price = q.ask
+		default:
+			price = (q.bid + q.ask) / 2
+		}
+
+		if price > 0 {
+			return price, true
+		}
+	}
+
+	if q, found := t.quotes[binance.Symbol(to+from)]; found {
+		var price float64
+
+		// Selling from is buying to at the ask, and the other way around.
+		switch t.priceType {
+		case BidPrice:
+			price = q.ask
+		case AskPrice:
+			price = q.bid
+		default:
+			price = (q.bid + q.ask) / 2
+		}
+
+		if price > 0 {
+			return 1 / price, true
+		}
+	}
+
+	return 0, false
+}
+
+// Rate returns the price of one from in to. It returns false if there's no
+// direct pair, and no pair through one of the bridges.
+func (t *PriceTable) Rate(from string, to string) (float64, bool) {
+	if from == to {
+		return 1, true
+	}
+
+	if price, found := t.direct(from, to); found {
+		return price, true
+	}
+
+	for _, bridge := range t.bridges {
+		if bridge == from || bridge == to {
+			continue
+		}
+
+		first, found := t.direct(from, bridge)
+		if !found {
+			continue
+		}
+
+		second, found := t.direct(bridge, to)
+		if found {
+			return first * second, true
+		}
+	}
+
+	return 0, false
+}
+
+// Source returns a PriceSource converting to quote using the table. The
+// time asked for is ignored.
+func (t *PriceTable) Source(quote string) PriceSource {
+	return &tableSource{table: t, quote: quote}
+}
+
+// tableSource is a PriceSource using a PriceTable.
+type tableSource struct {
+	table *PriceTable
+	quote string
+}
+
+// Price implements PriceSource.
+func (s *tableSource) Price(asset string, at time.Time) (float64, error) {
+	price, found := s.table.Rate(asset, s.quote)
+	if !found {
+		return 0, fmt.Errorf("no price for %s in %s", asset, s.quote)
+	}
+
+	return price, nil
+}
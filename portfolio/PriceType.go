@@ -0,0 +1,44 @@
+package portfolio
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// PriceType decides which price is used when converting between assets.
+type PriceType string
+
+// The different price types. Bid values assets at what they can be sold
+// for, and Ask at what they can be bought for.
+const (
+	LastPrice PriceType = "LAST"
+	BidPrice  PriceType = "BID"
+	AskPrice  PriceType = "ASK"
+	MidPrice  PriceType = "MID"
+)
+
+// UnmarshalJSON implements json.Unmarshaler while making sure only enums
+// that we know about end up in a PriceType variable.
+func (p *PriceType) UnmarshalJSON(data []byte) error {
+	s := ""
+	err := json.Unmarshal(data, &s)
+	if err != nil {
+		return err
+	}
+
+	priceType := PriceType(s)
+
+	switch priceType {
+	case LastPrice, BidPrice, AskPrice, MidPrice:
+		*p = priceType
+	default:
+		return fmt.Errorf("%s is not a valid price type", s)
+	}
+
+	return nil
+}
+
+// String implement Stringer.
+func (p PriceType) String() string {
+	return string(p)
+}
@@ -0,0 +1,431 @@
+// Package portfolio keeps track of positions, cost basis and profit and loss
+// from the trades of an account, and values accounts in any quote asset.
+package portfolio
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	binance "github.com/algoholdet/gobinance"
+)
+
+// Position is the holding of a single asset. All values are in the quote
+// asset of the tracker.
+type Position struct {
+	Asset        string
+	Quantity     float64
+	CostBasis    float64
+	AverageEntry float64
+	Realized     float64
+}
+
+// lot is a quantity acquired at a unit cost.
+type lot struct {
+	quantity float64
+	cost     float64
+}
+
+// position is the state kept per asset.
+type position struct {
+	lots     []lot
+	realized float64
+}
+
+// tradeKey identifies a trade, trade IDs are only unique per symbol.
+type tradeKey struct {
+	symbol binance.Symbol
+	id     int64
+}
+
+// Option is used for configuring a Tracker.
+type Option func(*Tracker)
+
+// Symbols sets the symbols the tracker knows about. Trades for other
+// symbols are rejected. The symbols are usually taken from ExchangeInfo().
+func Symbols(symbols ...binance.SymbolInfo) Option {
+	return func(t *Tracker) {
+		for _, s := range symbols {
+			t.symbols[binance.Symbol(s.Symbol.UpperCase())] = s
+		}
+	}
+}
+
+// Conversion sets the source used for converting to the quote asset of the
+// tracker at the time of a trade. It's needed for trades not quoted in the
+// quote asset of the tracker, and for commission paid in other assets,
+// like BNB.
+func Conversion(prices PriceSource) Option {
+	return func(t *Tracker) {
+		t.prices = prices
+	}
+}
+
+// Tracker keeps the positions of an account and the realized profit and
+// loss, as trades are added. All values are kept in a single quote asset,
+// like USDT. The quote asset itself is considered cash, and no position is
+// kept for it.
+//
+// Commission paid in the asset received is deducted from the quantity
+// received, making the cost per unit higher, or the proceeds lower when
+// receiving the quote asset. Commission paid in other
+// assets, like BNB, disposes of the commission asset for nothing, realizing
+// a loss of its cost basis. Commission not covered by the tracked position
+// is valued using the conversion, and realized as a loss. Otherwise,
+// disposing of more than the tracked position realizes nothing for the
+// excess, as the cost basis is unknown.
+//
+// Trades are identified by symbol and trade ID, and added only once. This
+// allows rebuilding from the history and following the user data stream at
+// the same time. It's safe to use from multiple goroutines.
+type Tracker struct {
+	method  Method
+	quote   string
+	symbols map[binance.Symbol]binance.SymbolInfo
+	prices  PriceSource
+
+	mu        sync.Mutex
+	positions map[string]*position
+	realized  float64
+	seen      map[tradeKey]bool
+}
+
+// NewTracker returns a tracker valuing everything in quote, using method
+// for deciding the cost basis.
+func NewTracker(method Method, quote string, options ...Option) *Tracker {
+	t := &Tracker{
+		method:    method,
+		quote:     quote,
+		symbols:   make(map[binance.Symbol]binance.SymbolInfo),
+		positions: make(map[string]*position),
+		seen:      make(map[tradeKey]bool),
+	}
+
+	for _, option := range options {
+		option(t)
+	}
+
+	return t
+}
+
+// rate returns the value of one unit of asset in the quote asset at at.
+func (t *Tracker) rate(asset string, at time.Time) (float64, error) {
+	if asset == t.quote {
+		return 1, nil
+	}
+
+	if t.prices == nil {
+		return 0, fmt.Errorf("no conversion from %s to %s", asset, t.quote)
+	}
+
+	return t.prices.Price(asset, at)
+}
+
+// Add will add trade to the positions. Trades should be added in the order
+// they happened, for the cost basis to be right.
+func (t *Tracker) Add(trade Trade) error {
+	symbol := binance.Symbol(trade.Symbol.UpperCase())
+
+	info, found := t.symbols[symbol]
+	if !found {
+		return fmt.Errorf("unknown symbol %s", trade.Symbol)
+	}
+
+	key := tradeKey{symbol, trade.ID}
+
+	receive, receiveQuantity := info.BaseAsset, trade.Quantity
+	pay, payQuantity := info.QuoteAsset, trade.Price*trade.Quantity
+	if !trade.Buy {
+		receive, receiveQuantity, pay, payQuantity = pay, payQuantity, receive, receiveQuantity
+	}
+
+	// Commission paid in another asset is only valued if the position
+	// doesn't cover it.
+	commission := trade.Commission > 0 && trade.CommissionAsset != receive
+	needed := trade.Commission
+	if trade.CommissionAsset == pay {
+		needed += payQuantity
+	}
+
+	t.mu.Lock()
+	seen := t.seen[key]
+	uncovered := commission && !t.covers(trade.CommissionAsset, needed)
+	t.mu.Unlock()
+
+	if seen {
+		return nil
+	}
+
+	// The conversion may call Binance, so we do that before locking.
+	rate, err := t.rate(info.QuoteAsset, trade.Time)
+	if err != nil {
+		return err
+	}
+
+	commissionRate := 0.0
+	if uncovered {
+		commissionRate, err = t.rate(trade.CommissionAsset, trade.Time)
+		if err != nil {
+			return err
+		}
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.seen[key] {
+		return nil
+	}
+
+	t.seen[key] = true
+
+	value := trade.Price * trade.Quantity * rate
+
+	if trade.CommissionAsset == receive {
+		receiveQuantity -= trade.Commission
+	}
+
+	// When receiving the quote asset, the commission lowers the proceeds,
+	// as no position is kept for it.
+	proceeds := value
+	if receive == t.quote {
+		proceeds = receiveQuantity
+	}
+
+	t.dispose(pay, payQuantity, proceeds)
+	t.acquire(receive, receiveQuantity, value)
+
+	if commission {
+		excess := t.dispose(trade.CommissionAsset, trade.Commission, 0)
+		t.realize(trade.CommissionAsset, -excess*commissionRate)
+	}
+
+	return nil
+}
+
+// AddTradeOrder will add a trade from the trade history.
+func (t *Tracker) AddTradeOrder(trade binance.TradeOrder) error {
+	return t.Add(FromTradeOrder(trade))
+}
+
+// AddExecutionReport will add the trade reported by report, if any.
+func (t *Tracker) AddExecutionReport(report *binance.ExecutionReportEvent) error {
+	trade, ok := FromExecutionReport(report)
+	if !ok {
+		return nil
+	}
+
+	return t.Add(trade)
+}
+
+// Rebuild will add all trades for symbols from start until end, in the order
+// they happened.
+func (t *Tracker) Rebuild(client *binance.Client, symbols []binance.Symbol, start binance.Time, end binance.Time) error {
+	var trades []binance.TradeOrder
+
+	for _, symbol := range symbols {
+		it := client.TradeHistory(symbol, start, end)
+		for it.Next() {
+			trades = append(trades, it.Trade())
+		}
+
+		if it.Err() != nil {
+			return it.Err()
+		}
+	}
+
+	sort.SliceStable(trades, func(i, j int) bool {
+		return trades[i].TimeStamp.Before(trades[j].TimeStamp.Time)
+	})
+
+	for _, trade := range trades {
+		err := t.AddTradeOrder(trade)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// position returns the position of asset.
+func (t *Tracker) position(asset string) *position {
+	p, found := t.positions[asset]
+	if !found {
+		p = &position{}
+		t.positions[asset] = p
+	}
+
+	return p
+}
+
+// acquire will add quantity of asset at a total cost of cost.
+func (t *Tracker) acquire(asset string, quantity float64, cost float64) {
+	if asset == t.quote || quantity <= 0 {
+		return
+	}
+
+	p := t.position(asset)
+
+	if t.method == AverageCost && len(p.lots) == 1 {
+		l := &p.lots[0]
+		total := l.quantity*l.cost + cost
+		l.quantity += quantity
+		l.cost = total / l.quantity
+
+		return
+	}
+
+	p.lots = append(p.lots, lot{quantity: quantity, cost: cost / quantity})
+}
+
+// covers returns true if the position of asset is at least quantity. The
+// quote asset is always covered.
+func (t *Tracker) covers(asset string, quantity float64) bool {
+	if asset == t.quote {
+		return true
+	}
+
+	return t.describe(asset).Quantity >= quantity
+}
+
+// realize will add pnl to the realized profit and loss of asset.
+func (t *Tracker) realize(asset string, pnl float64) {
+	if pnl == 0 {
+		return
+	}
+
+	t.position(asset).realized += pnl
+	t.realized += pnl
+}
+
+// dispose will remove quantity of asset for proceeds, and realize the
+// difference from the cost basis. It returns the quantity not covered by
+// the position.
+func (t *Tracker) dispose(asset string, quantity float64, proceeds float64) float64 {
+	if quantity <= 0 {
+		return 0
+	}
+
+	if asset == t.quote {
+		t.realized += proceeds - quantity
+		return 0
+	}
+
+	p := t.position(asset)
+
+	remaining := quantity
+	basis := 0.0
+
+	for remaining > 0 && len(p.lots) > 0 {
+		i := 0
+		if t.method == LIFO {
+			i = len(p.lots) - 1
+		}
+
+		l := &p.lots[i]
+
+		used := l.quantity
+		if used > remaining {
+			used = remaining
+		}
+
+		basis += used * l.cost
+		l.quantity -= used
+		remaining -= used
+
+		if l.quantity <= 0 {
+			p.lots = append(p.lots[:i], p.lots[i+1:]...)
+		}
+	}
+
+	// Only the part we knew the cost basis of is realized.
+	realized := proceeds*(quantity-remaining)/quantity - basis
+
+	p.realized += realized
+	t.realized += realized
+
+	return remaining
+}
+
+// Realized returns the total realized profit and loss.
+func (t *Tracker) Realized() float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.realized
+}
+
+// Position returns the position of asset.
+func (t *Tracker) Position(asset string) Position {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.describe(asset)
+}
+
+// describe returns the position of asset.
+func (t *Tracker) describe(asset string) Position {
+	position := Position{Asset: asset}
+
+	p, found := t.positions[asset]
+	if !found {
+		return position
+	}
+
+	for _, l := range p.lots {
+		position.Quantity += l.quantity
+		position.CostBasis += l.quantity * l.cost
+	}
+
+	if position.Quantity > 0 {
+		position.AverageEntry = position.CostBasis / position.Quantity
+	}
+
+	position.Realized = p.realized
+
+	return position
+}
+
+// Positions returns all positions sorted by asset.
+func (t *Tracker) Positions() []Position {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	assets := make([]string, 0, len(t.positions))
+	for asset := range t.positions {
+		assets = append(assets, asset)
+	}
+
+	sort.Strings(assets)
+
+	positions := make([]Position, len(assets))
+	for i, asset := range assets {
+		positions[i] = t.describe(asset)
+	}
+
+	return positions
+}
+
+// Unrealized returns the unrealized profit and loss of all positions, valued
+// using prices at the current time.
+func (t *Tracker) Unrealized(prices PriceSource) (float64, error) {
+	unrealized := 0.0
+	now := time.Now()
+
+	for _, p := range t.Positions() {
+		if p.Quantity <= 0 {
+			continue
+		}
+
+		price, err := prices.Price(p.Asset, now)
+		if err != nil {
+			return 0, err
+		}
+
+		unrealized += p.Quantity*price - p.CostBasis
+	}
+
+	return unrealized, nil
+}
@@ -0,0 +1,228 @@
+package portfolio
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	binance "github.com/algoholdet/gobinance"
+)
+
+var (
+	btcusdt = binance.SymbolInfo{Symbol: "BTCUSDT", BaseAsset: "BTC", QuoteAsset: "USDT"}
+	bnbusdt = binance.SymbolInfo{Symbol: "BNBUSDT", BaseAsset: "BNB", QuoteAsset: "USDT"}
+	ethbtc  = binance.SymbolInfo{Symbol: "ETHBTC", BaseAsset: "ETH", QuoteAsset: "BTC"}
+)
+
+// prices is a PriceSource with fixed prices.
+type prices map[string]float64
+
+func (p prices) Price(asset string, at time.Time) (float64, error) {
+	price, found := p[asset]
+	if !found {
+		return 0, fmt.Errorf("no price for %s", asset)
+	}
+
+	return price, nil
+}
+
+func near(a float64, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestTrackerMethods(t *testing.T) {
+	trades := []Trade{
+		{Symbol: "BTCUSDT", ID: 1, Buy: true, Price: 100, Quantity: 1},
+		{Symbol: "BTCUSDT", ID: 2, Buy: true, Price: 200, Quantity: 1},
+		{Symbol: "BTCUSDT", ID: 3, Buy: false, Price: 300, Quantity: 1},
+	}
+
+	cases := []struct {
+		method     Method
+		realized   float64
+		costBasis  float64
+		unrealized float64
+	}{
+		{FIFO, 200, 200, 200},
+		{LIFO, 100, 100, 300},
+		{AverageCost, 150, 150, 250},
+	}
+
+	for _, c := range cases {
+		tracker := NewTracker(c.method, "USDT", Symbols(btcusdt))
+
+		for _, trade := range trades {
+			err := tracker.Add(trade)
+			if err != nil {
+				t.Fatalf("%s: Add failed: %s", c.method, err.Error())
+			}
+		}
+
+		p := tracker.Position("BTC")
+		if p.Quantity != 1 || p.CostBasis != c.costBasis || p.AverageEntry != c.costBasis || p.Realized != c.realized {
+			t.Errorf("%s: got position %+v", c.method, p)
+		}
+
+		if tracker.Realized() != c.realized {
+			t.Errorf("%s: realized %f, expected %f", c.method, tracker.Realized(), c.realized)
+		}
+
+		unrealized, err := tracker.Unrealized(prices{"BTC": 400})
+		if err != nil || unrealized != c.unrealized {
+			t.Errorf("%s: unrealized %f %v, expected %f", c.method, unrealized, err, c.unrealized)
+		}
+	}
+}
+
+func TestTrackerCommission(t *testing.T) {
+	tracker := NewTracker(FIFO, "USDT", Symbols(btcusdt, bnbusdt))
+
+	trades := []Trade{
+		// Commission in the received asset makes the BNB more expensive.
+		{Symbol: "BNBUSDT", ID: 1, Buy: true, Price: 10, Quantity: 10, Commission: 0.5, CommissionAsset: "BNB"},
+		// Commission in BNB is a loss of its cost basis.
+		{Symbol: "BTCUSDT", ID: 1, Buy: true, Price: 100, Quantity: 1, Commission: 0.1, CommissionAsset: "BNB"},
+		// Commission in the received quote asset lowers the proceeds.
+		{Symbol: "BTCUSDT", ID: 2, Buy: false, Price: 110, Quantity: 1, Commission: 0.11, CommissionAsset: "USDT"},
+	}
+
+	for _, trade := range trades {
+		err := tracker.Add(trade)
+		if err != nil {
+			t.Fatalf("Add failed: %s", err.Error())
+		}
+	}
+
+	bnb := tracker.Position("BNB")
+	if !near(bnb.Quantity, 9.4) || !near(bnb.AverageEntry, 100.0/9.5) || !near(bnb.Realized, -0.1*100/9.5) {
+		t.Errorf("got BNB position %+v", bnb)
+	}
+
+	btc := tracker.Position("BTC")
+	if btc.Quantity != 0 || !near(btc.Realized, 9.89) {
+		t.Errorf("got BTC position %+v", btc)
+	}
+
+	if !near(tracker.Realized(), 10-0.11-0.1*100/9.5) {
+		t.Errorf("got realized %f", tracker.Realized())
+	}
+
+	// Commission in BNB not held is valued using the conversion.
+	trade := Trade{Symbol: "BTCUSDT", ID: 1, Buy: true, Price: 100, Quantity: 1, Commission: 0.01, CommissionAsset: "BNB"}
+
+	tracker = NewTracker(FIFO, "USDT", Symbols(btcusdt))
+	if err := tracker.Add(trade); err == nil {
+		t.Errorf("Add without conversion succeeded")
+	}
+
+	tracker = NewTracker(FIFO, "USDT", Symbols(btcusdt), Conversion(prices{"BNB": 300}))
+	if err := tracker.Add(trade); err != nil {
+		t.Fatalf("Add failed: %s", err.Error())
+	}
+
+	if !near(tracker.Realized(), -3) || !near(tracker.Position("BNB").Realized, -3) {
+		t.Errorf("got realized %f, BNB position %+v", tracker.Realized(), tracker.Position("BNB"))
+	}
+}
+
+func TestTrackerConversion(t *testing.T) {
+	tracker := NewTracker(FIFO, "USDT", Symbols(ethbtc))
+
+	trade := Trade{Symbol: "ETHBTC", ID: 1, Buy: true, Price: 0.05, Quantity: 2}
+
+	err := tracker.Add(trade)
+	if err == nil {
+		t.Fatalf("Add without conversion succeeded")
+	}
+
+	tracker = NewTracker(FIFO, "USDT", Symbols(ethbtc), Conversion(prices{"BTC": 20000}))
+
+	err = tracker.Add(trade)
+	if err != nil {
+		t.Fatalf("Add failed: %s", err.Error())
+	}
+
+	// The BTC paid was never bought, so nothing is realized.
+	positions := tracker.Positions()
+	if len(positions) != 2 || positions[0].Asset != "BTC" || positions[0].Realized != 0 ||
+		positions[1].Asset != "ETH" || positions[1].Quantity != 2 || positions[1].CostBasis != 2000 {
+		t.Errorf("got positions %+v", positions)
+	}
+
+	err = tracker.Add(Trade{Symbol: "XRPBTC", ID: 1})
+	if err == nil {
+		t.Errorf("Add succeeded for unknown symbol")
+	}
+}
+
+func TestTrackerDuplicates(t *testing.T) {
+	tracker := NewTracker(FIFO, "USDT", Symbols(btcusdt))
+
+	report := &binance.ExecutionReportEvent{
+		Symbol:               "BTCUSDT",
+		Side:                 binance.OrderSideBuy,
+		ExecutionType:        binance.ExecutionTypeTrade,
+		TradeID:              7,
+		LastExecutedPrice:    "100",
+		LastExecutedQuantity: "1",
+	}
+
+	for i := 0; i < 2; i++ {
+		err := tracker.AddExecutionReport(report)
+		if err != nil {
+			t.Fatalf("AddExecutionReport failed: %s", err.Error())
+		}
+	}
+
+	err := tracker.AddTradeOrder(binance.TradeOrder{Symbol: "btcusdt", ID: 7, IsBuyer: true, Price: "100", Quantity: "1"})
+	if err != nil {
+		t.Fatalf("AddTradeOrder failed: %s", err.Error())
+	}
+
+	if p := tracker.Position("BTC"); p.Quantity != 1 {
+		t.Errorf("duplicates added: %+v", p)
+	}
+
+	report.ExecutionType = binance.ExecutionTypeNew
+	report.TradeID = 8
+
+	err = tracker.AddExecutionReport(report)
+	if err != nil || tracker.Position("BTC").Quantity != 1 {
+		t.Errorf("non-trade report added: %v", err)
+	}
+}
+
+func TestTrackerRebuild(t *testing.T) {
+	account := binance.NewPaperAccount(
+		binance.PaperSymbols(btcusdt),
+		binance.PaperBalance("USDT", "1000"),
+	)
+
+	client, err := binance.NewClient(binance.BaseURL("http://localhost:1"), binance.PaperTrading(account))
+	if err != nil {
+		t.Fatalf("NewClient failed: %s", err.Error())
+	}
+
+	for _, price := range []binance.Value{"100", "200"} {
+		account.Tick("BTCUSDT", price, binance.Time{})
+
+		order, _ := binance.MarketBuy("BTCUSDT", "1")
+		err = client.SubmitOrder(order)
+		if err != nil {
+			t.Fatalf("SubmitOrder failed: %s", err.Error())
+		}
+	}
+
+	tracker := NewTracker(AverageCost, "USDT", Symbols(btcusdt))
+
+	now := binance.FromTime(time.Now().Add(time.Minute))
+	err = tracker.Rebuild(client, []binance.Symbol{"BTCUSDT"}, binance.FromTime(now.Add(-time.Hour)), now)
+	if err != nil {
+		t.Fatalf("Rebuild failed: %s", err.Error())
+	}
+
+	if p := tracker.Position("BTC"); p.Quantity != 2 || p.AverageEntry != 150 {
+		t.Errorf("got position %+v", p)
+	}
+}
@@ -0,0 +1,54 @@
+package portfolio
+
+import (
+	"time"
+
+	binance "github.com/algoholdet/gobinance"
+)
+
+// Trade is a single fill as seen by the tracker.
+type Trade struct {
+	Symbol binance.Symbol
+	ID     int64
+	Time   time.Time
+	Buy    bool
+
+	Price           float64
+	Quantity        float64
+	Commission      float64
+	CommissionAsset string
+}
+
+// FromTradeOrder returns the trade described by trade from the trade
+// history.
+func FromTradeOrder(trade binance.TradeOrder) Trade {
+	return Trade{
+		Symbol:          trade.Symbol,
+		ID:              trade.ID,
+		Time:            trade.TimeStamp.Time,
+		Buy:             trade.IsBuyer,
+		Price:           trade.Price.Float64(),
+		Quantity:        trade.Quantity.Float64(),
+		Commission:      trade.Commission.Float64(),
+		CommissionAsset: trade.CommissionAsset,
+	}
+}
+
+// FromExecutionReport returns the trade described by report from the user
+// data stream. It returns false if the report is not describing a trade.
+func FromExecutionReport(report *binance.ExecutionReportEvent) (Trade, bool) {
+	if report.ExecutionType != binance.ExecutionTypeTrade {
+		return Trade{}, false
+	}
+
+	return Trade{
+		Symbol:          report.Symbol,
+		ID:              report.TradeID,
+		Time:            report.TransactionTime.Time,
+		Buy:             report.Side == binance.OrderSideBuy,
+		Price:           report.LastExecutedPrice.Float64(),
+		Quantity:        report.LastExecutedQuantity.Float64(),
+		Commission:      report.Commission.Float64(),
+		CommissionAsset: report.CommissionAsset,
+	}, true
+}
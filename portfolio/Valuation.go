@@ -0,0 +1,79 @@
+package portfolio
+
+import (
+	"sort"
+
+	binance "github.com/algoholdet/gobinance"
+)
+
+// AssetValue is the value of the balance of a single asset.
+type AssetValue struct {
+	Asset    string
+	Quantity float64
+	Price    float64
+	Value    float64
+
+	// Weight is the share of the total value, from 0 to 1.
+	Weight float64
+}
+
+// Valuation is the value of an account in a single quote asset.
+type Valuation struct {
+	Quote string
+	Total float64
+
+	// Assets are the valued assets, the most valuable first.
+	Assets []AssetValue
+
+	// Unpriced are the assets that could not be converted to the quote
+	// asset. They are not included in the total.
+	Unpriced []string
+}
+
+// ValueAccount returns the value of the free and locked balances of
+// account in quote.
+func ValueAccount(account *binance.AccountInfo, prices *PriceTable, quote string) *Valuation {
+	return ValueBalances(account.NonZero(), prices, quote)
+}
+
+// ValueBalances returns the value of balances in quote.
+func ValueBalances(balances []binance.Balance, prices *PriceTable, quote string) *Valuation {
+	v := &Valuation{Quote: quote}
+
+	for _, b := range balances {
+		quantity := b.Total()
+		if quantity == 0 {
+			continue
+		}
+
+		price, found := prices.Rate(b.Asset, quote)
+		if !found {
+			v.Unpriced = append(v.Unpriced, b.Asset)
+			continue
+		}
+
+		a := AssetValue{
+			Asset:    b.Asset,
+			Quantity: quantity,
+			Price:    price,
+			Value:    quantity * price,
+		}
+
+		v.Assets = append(v.Assets, a)
+		v.Total += a.Value
+	}
+
+	if v.Total > 0 {
+		for i := range v.Assets {
+			v.Assets[i].Weight = v.Assets[i].Value / v.Total
+		}
+	}
+
+	sort.SliceStable(v.Assets, func(i, j int) bool {
+		return v.Assets[i].Value > v.Assets[j].Value
+	})
+
+	sort.Strings(v.Unpriced)
+
+	return v
+}
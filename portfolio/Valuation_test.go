@@ -0,0 +1,96 @@
+package portfolio
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	binance "github.com/algoholdet/gobinance"
+)
+
+func TestPriceTableRate(t *testing.T) {
+	table := newPriceTable(BidPrice, map[binance.Symbol]quote{
+		"BTCUSDT": {bid: 20000, ask: 20010},
+		"ETHBTC":  {bid: 0.05, ask: 0.051},
+		"BNBUSDT": {bid: 200, ask: 201},
+		"XYZBNB":  {bid: 0.5, ask: 0.6},
+		"EURUSDT": {bid: 1.25, ask: 1.26},
+		"ABCDEF":  {bid: 1, ask: 1},
+	}, nil)
+
+	cases := []struct {
+		from     string
+		to       string
+		expected float64
+		found    bool
+	}{
+		{"BTC", "BTC", 1, true},
+		{"BTC", "USDT", 20000, true},
+		// Selling USDT for BTC is buying BTC at the ask.
+		{"USDT", "BTC", 1.0 / 20010, true},
+		{"ETH", "USDT", 0.05 * 20000, true},
+		{"XYZ", "USDT", 0.5 * 200, true},
+		{"BTC", "EUR", 20000 / 1.26, true},
+		// Two bridges are needed for this one.
+		{"XYZ", "EUR", 0, false},
+		{"ABC", "USDT", 0, false},
+	}
+
+	for _, c := range cases {
+		rate, found := table.Rate(c.from, c.to)
+		if found != c.found || !near(rate, c.expected) {
+			t.Errorf("%s in %s: got %g %v, expected %g %v", c.from, c.to, rate, found, c.expected, c.found)
+		}
+	}
+}
+
+func TestValueAccount(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v3/ticker/price":
+			fmt.Fprint(w, `[{"symbol":"BTCUSDT","price":"20000"},{"symbol":"ETHBTC","price":"0.05"}]`)
+		case "/api/v3/ticker/bookTicker":
+			fmt.Fprint(w, `[{"symbol":"BTCUSDT","bidPrice":"19990","bidQty":"1","askPrice":"20010","askQty":"1"},{"symbol":"ETHBTC","bidPrice":"0.049","bidQty":"1","askPrice":"0.051","askQty":"1"}]`)
+		default:
+			t.Errorf("unexpected request for %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, _ := binance.NewClient(binance.BaseURL(server.URL))
+
+	account := &binance.AccountInfo{Balances: []binance.Balance{
+		{Asset: "BTC", Free: "0.5", Locked: "0.5"},
+		{Asset: "ETH", Free: "10", Locked: "0"},
+		{Asset: "USDT", Free: "0", Locked: "0"},
+		{Asset: "XYZ", Free: "100", Locked: "0"},
+	}}
+
+	cases := []struct {
+		priceType PriceType
+		total     float64
+	}{
+		{LastPrice, 30000},
+		{MidPrice, 30000},
+		{BidPrice, 19990 + 0.049*19990*10},
+	}
+
+	for _, c := range cases {
+		table, err := NewPriceTable(client, c.priceType)
+		if err != nil {
+			t.Fatalf("%s: NewPriceTable failed: %s", c.priceType, err.Error())
+		}
+
+		v := ValueAccount(account, table, "USDT")
+		if !near(v.Total, c.total) || len(v.Assets) != 2 || v.Assets[0].Asset != "BTC" ||
+			!near(v.Assets[0].Weight+v.Assets[1].Weight, 1) || fmt.Sprint(v.Unpriced) != "[XYZ]" {
+			t.Errorf("%s: got %+v", c.priceType, v)
+		}
+	}
+
+	_, err := NewPriceTable(client, "CLOSE")
+	if err == nil {
+		t.Errorf("NewPriceTable accepted invalid price type")
+	}
+}
@@ -7,9 +7,11 @@ import (
 // query is used to query various API endpoints.
 type query struct {
 	fromID    *int64
+	orderID   *int64
 	startTime *Time
 	endTime   *Time
 	limit     *int
+	offset    *int
 }
 
 // QueryFunc is the function signature to use for setting various query
@@ -34,6 +36,14 @@ func FromID(id int64) QueryFunc {
 	}
 }
 
+// OrderID can be used for limiting a query to the order with ID id, or for
+// listing orders starting from id.
+func OrderID(id int64) QueryFunc {
+	return func(q *query) {
+		q.orderID = &id
+	}
+}
+
 // StartTime will set a start time for the query. The time is inclusive.
 func StartTime(start Time) QueryFunc {
 	return func(q *query) {
@@ -55,6 +65,13 @@ func Limit(limit int) QueryFunc {
 	}
 }
 
+// Offset can be used for skipping the first offset objects of a query.
+func Offset(offset int) QueryFunc {
+	return func(q *query) {
+		q.offset = &offset
+	}
+}
+
 // params can be passed to URL builders.
 func (q *query) params() func(url.Values) {
 	return func(v url.Values) {
@@ -62,6 +79,10 @@ func (q *query) params() func(url.Values) {
 			param("fromId", *q.fromID)(v)
 		}
 
+		if q.orderID != nil {
+			param("orderId", *q.orderID)(v)
+		}
+
 		if q.startTime != nil {
 			param("startTime", q.startTime.UnixNano()/1000000)(v)
 		}
@@ -73,5 +94,9 @@ func (q *query) params() func(url.Values) {
 		if q.limit != nil {
 			param("limit", *q.limit)(v)
 		}
+
+		if q.offset != nil {
+			param("offset", *q.offset)(v)
+		}
 	}
 }
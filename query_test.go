@@ -16,6 +16,8 @@ func TestNewQuery(t *testing.T) {
 		{[]QueryFunc{FromID(10)}, "fromId=10"},
 		{[]QueryFunc{FromID(10), StartTime(FromTime(time.Time{}))}, "fromId=10&startTime=-6795364578871"},
 		{[]QueryFunc{EndTime(FromTime(time.Time{}))}, "endTime=-6795364578871"},
+		{[]QueryFunc{OrderID(42), Limit(10)}, "limit=10&orderId=42"},
+		{[]QueryFunc{Limit(1000), Offset(2000)}, "limit=1000&offset=2000"},
 	}
 
 	for i, c := range cases {
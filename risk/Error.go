@@ -0,0 +1,36 @@
+package risk
+
+import (
+	"fmt"
+)
+
+// Rule names a check done by the guard.
+type Rule string
+
+// The rules enforced by the guard.
+const (
+	RuleMaxNotional   Rule = "MAX_NOTIONAL"
+	RuleMaxPosition   Rule = "MAX_POSITION"
+	RuleMaxOpenOrders Rule = "MAX_OPEN_ORDERS"
+	RulePriceBand     Rule = "PRICE_BAND"
+	RuleDailyLoss     Rule = "DAILY_LOSS"
+	RuleKillSwitch    Rule = "KILL_SWITCH"
+)
+
+// String implement Stringer.
+func (r Rule) String() string {
+	return string(r)
+}
+
+// Error is returned when the guard rejects an order. The order is never sent
+// to Binance.
+type Error struct {
+	Rule   Rule
+	Symbol string
+	Reason string
+}
+
+// Error implements error.
+func (e *Error) Error() string {
+	return fmt.Sprintf("order for %s rejected by %s: %s", e.Symbol, e.Rule, e.Reason)
+}
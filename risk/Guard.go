@@ -0,0 +1,537 @@
+// Package risk checks orders against configurable limits before they are
+// sent to Binance.
+package risk
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	binance "github.com/algoholdet/gobinance"
+)
+
+// Client is used by the guard for submitting orders and looking up the
+// market and the account. *binance.Client implements it.
+type Client interface {
+	SubmitOrder(order *binance.Order) error
+	CancelReplaceOrder(mode binance.CancelReplaceMode, cancelClientOrderID string, cancelID int, order *binance.Order) (*binance.CancelReplaceResult, error)
+	SubmitOCO(oco *binance.OCO) (*binance.OrderList, error)
+	SubmitOTO(oto *binance.OTO) (*binance.OrderList, error)
+	SubmitOTOCO(otoco *binance.OTOCO) (*binance.OrderList, error)
+	OrderStatus(symbol binance.Symbol, clientOrderID string, id int) (*binance.Order, error)
+	CancelOrder(symbol binance.Symbol, clientOrderID string, id int) (*binance.Order, error)
+	CancelAllOrders(symbol binance.Symbol) ([]binance.Order, []binance.OrderList, error)
+	OpenOrders(symbol binance.Symbol) ([]binance.Order, error)
+	BestPrice(symbol binance.Symbol) (*binance.BestPrice, error)
+	AccountInfo() (*binance.AccountInfo, error)
+}
+
+// RealizedPnL returns the realized profit and loss of the account.
+// *portfolio.Tracker implements it.
+type RealizedPnL interface {
+	Realized() float64
+}
+
+// Option is used for configuring a Guard.
+type Option func(*Guard)
+
+// Symbols sets the symbols the guard knows about. Orders for other symbols
+// are rejected when a limit on notional or positions is set.
+func Symbols(symbols ...binance.SymbolInfo) Option {
+	return func(g *Guard) {
+		for _, s := range symbols {
+			g.symbols[binance.Symbol(s.Symbol.UpperCase())] = s
+		}
+	}
+}
+
+// MaxNotional limits the value of each order for symbols quoted in asset.
+// It can be used once per quote asset. Orders for unknown symbols, and for
+// symbols quoted in an asset without a limit, are rejected. See Symbols().
+func MaxNotional(asset string, notional float64) Option {
+	return func(g *Guard) {
+		g.maxNotional[asset] = notional
+	}
+}
+
+// MaxPosition limits the quantity of asset held, counting the balance and
+// the quantity bought by open orders on the same symbol. Orders for
+// unknown symbols are rejected. See Symbols().
+func MaxPosition(asset string, quantity float64) Option {
+	return func(g *Guard) {
+		g.maxPosition[asset] = quantity
+	}
+}
+
+// MaxOpenOrders limits the number of open orders per symbol.
+func MaxOpenOrders(orders int) Option {
+	return func(g *Guard) {
+		g.maxOpenOrders = orders
+	}
+}
+
+// PriceBand rejects limit prices further than band from the best price in
+// the order book. Buys are compared to the best ask, and sells to the best
+// bid. A band of 0.05 allows prices within 5%.
+func PriceBand(band float64) Option {
+	return func(g *Guard) {
+		g.priceBand = band
+	}
+}
+
+// DailyLossLimit blocks new orders once the realized loss of the day reaches
+// limit. The loss is measured from the realized PnL when the guard is
+// created, and from the first order after midnight UTC on later days.
+func DailyLossLimit(pnl RealizedPnL, limit float64) Option {
+	return func(g *Guard) {
+		g.pnl = pnl
+		g.dailyLoss = limit
+	}
+}
+
+// Guard checks orders against the configured limits before submitting
+// them. An order breaking a limit is rejected with an *Error, and never sent
+// to Binance. Orders are checked and submitted one at a time, so concurrent
+// orders can't slip past the limits together.
+//
+// Only orders placed through the guard are checked. Orders placed directly
+// on the client, or through a WSAPIClient, bypass all limits including
+// Kill().
+//
+// Guard can be used as an execution.Client.
+type Guard struct {
+	client  Client
+	symbols map[binance.Symbol]binance.SymbolInfo
+
+	maxNotional   map[string]float64
+	maxPosition   map[string]float64
+	maxOpenOrders int
+	priceBand     float64
+	pnl           RealizedPnL
+	dailyLoss     float64
+
+	// now is replaced in tests.
+	now func() time.Time
+
+	// submit serializes checking and submitting orders.
+	submit sync.Mutex
+
+	mu     sync.Mutex
+	killed bool
+	day    time.Time
+	dayPnL float64
+}
+
+// New returns a guard submitting orders through client.
+func New(client Client, options ...Option) *Guard {
+	g := &Guard{
+		client:      client,
+		symbols:     make(map[binance.Symbol]binance.SymbolInfo),
+		maxNotional: make(map[string]float64),
+		maxPosition: make(map[string]float64),
+		now:         time.Now,
+	}
+
+	for _, option := range options {
+		option(g)
+	}
+
+	if g.pnl != nil {
+		g.dailyStart()
+	}
+
+	return g
+}
+
+// Kill blocks all new orders and cancels all open orders. It returns the
+// first error seen while canceling, but will try all symbols.
+func (g *Guard) Kill() error {
+	g.mu.Lock()
+	g.killed = true
+	g.mu.Unlock()
+
+	// Wait for an order being submitted, so it will be canceled too.
+	g.submit.Lock()
+	defer g.submit.Unlock()
+
+	orders, err := g.client.OpenOrders("")
+	if err != nil {
+		return err
+	}
+
+	seen := make(map[binance.Symbol]bool)
+
+	var first error
+	for _, order := range orders {
+		if seen[order.Symbol] {
+			continue
+		}
+
+		seen[order.Symbol] = true
+
+		_, _, err = g.client.CancelAllOrders(order.Symbol)
+		if err != nil && first == nil {
+			first = err
+		}
+	}
+
+	return first
+}
+
+// Resume allows new orders again after Kill().
+func (g *Guard) Resume() {
+	g.mu.Lock()
+	g.killed = false
+	g.mu.Unlock()
+}
+
+// Killed returns true if new orders are blocked by Kill().
+func (g *Guard) Killed() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.killed
+}
+
+// SubmitOrder will check order against the limits, and submit it if none
+// are broken.
+func (g *Guard) SubmitOrder(order *binance.Order) error {
+	g.submit.Lock()
+	defer g.submit.Unlock()
+
+	err := g.Check(order)
+	if err != nil {
+		return err
+	}
+
+	return g.client.SubmitOrder(order)
+}
+
+// CancelReplaceOrder will check order against the limits, and cancel and
+// replace if none are broken. With CancelReplaceStopOnFailure, the canceled
+// order doesn't count towards the limits.
+func (g *Guard) CancelReplaceOrder(mode binance.CancelReplaceMode, cancelClientOrderID string, cancelID int, order *binance.Order) (*binance.CancelReplaceResult, error) {
+	g.submit.Lock()
+	defer g.submit.Unlock()
+
+	var replaced func(binance.Order) bool
+	if mode == binance.CancelReplaceStopOnFailure {
+		replaced = func(o binance.Order) bool {
+			return (cancelID != 0 && o.ID == cancelID) ||
+				(cancelClientOrderID != "" && o.ClientOrderID == cancelClientOrderID)
+		}
+	}
+
+	err := g.check(order, 1, replaced)
+	if err != nil {
+		return nil, err
+	}
+
+	return g.client.CancelReplaceOrder(mode, cancelClientOrderID, cancelID, order)
+}
+
+// SubmitOCO will check both orders of oco against the limits, and submit
+// the pair if none are broken.
+func (g *Guard) SubmitOCO(oco *binance.OCO) (*binance.OrderList, error) {
+	g.submit.Lock()
+	defer g.submit.Unlock()
+
+	err := g.checkList(
+		listLeg(oco.Above, oco.Symbol, oco.Side, oco.Quantity),
+		listLeg(oco.Below, oco.Symbol, oco.Side, oco.Quantity),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return g.client.SubmitOCO(oco)
+}
+
+// SubmitOTO will check both orders of oto against the limits, and submit
+// the pair if none are broken.
+func (g *Guard) SubmitOTO(oto *binance.OTO) (*binance.OrderList, error) {
+	g.submit.Lock()
+	defer g.submit.Unlock()
+
+	err := g.checkList(oto.Working, oto.Pending)
+	if err != nil {
+		return nil, err
+	}
+
+	return g.client.SubmitOTO(oto)
+}
+
+// SubmitOTOCO will check all orders of otoco against the limits, and submit
+// the list if none are broken.
+func (g *Guard) SubmitOTOCO(otoco *binance.OTOCO) (*binance.OrderList, error) {
+	g.submit.Lock()
+	defer g.submit.Unlock()
+
+	if otoco.Working == nil || otoco.PendingAbove == nil {
+		return nil, errors.New("missing working or pending order")
+	}
+
+	above := otoco.PendingAbove
+	err := g.checkList(
+		otoco.Working,
+		listLeg(above, otoco.Working.Symbol, above.Side, above.Quantity),
+		listLeg(otoco.PendingBelow, otoco.Working.Symbol, above.Side, above.Quantity),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return g.client.SubmitOTOCO(otoco)
+}
+
+// listLeg returns a copy of leg with the symbol, side and quantity some
+// lists take from the list, or nil if leg is nil.
+func listLeg(leg *binance.Order, symbol binance.Symbol, side binance.OrderSide, quantity binance.Value) *binance.Order {
+	if leg == nil {
+		return nil
+	}
+
+	order := *leg
+	order.Symbol, order.Side, order.Quantity = symbol, side, quantity
+
+	return &order
+}
+
+// checkList will check each order of a list as a single order, placed along
+// with the others.
+func (g *Guard) checkList(legs ...*binance.Order) error {
+	for _, leg := range legs {
+		if leg == nil {
+			return errors.New("missing order in list")
+		}
+	}
+
+	for _, leg := range legs {
+		err := g.check(leg, len(legs), nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// OrderStatus queries the status of an order.
+func (g *Guard) OrderStatus(symbol binance.Symbol, clientOrderID string, id int) (*binance.Order, error) {
+	return g.client.OrderStatus(symbol, clientOrderID, id)
+}
+
+// CancelOrder cancels a live order. Canceling is always allowed.
+func (g *Guard) CancelOrder(symbol binance.Symbol, clientOrderID string, id int) (*binance.Order, error) {
+	return g.client.CancelOrder(symbol, clientOrderID, id)
+}
+
+// reject returns an *Error for order.
+func reject(rule Rule, order *binance.Order, format string, a ...interface{}) error {
+	return &Error{
+		Rule:   rule,
+		Symbol: string(order.Symbol.UpperCase()),
+		Reason: fmt.Sprintf(format, a...),
+	}
+}
+
+// Check will check order against the limits without submitting it. Errors
+// from looking up the market or the account are returned as is.
+func (g *Guard) Check(order *binance.Order) error {
+	return g.check(order, 1, nil)
+}
+
+// check will check order, placed along with placed-1 other orders. Open
+// orders matching replaced are ignored, as they are canceled first.
+func (g *Guard) check(order *binance.Order, placed int, replaced func(binance.Order) bool) error {
+	if g.Killed() {
+		return reject(RuleKillSwitch, order, "trading is halted")
+	}
+
+	err := g.checkDailyLoss(order)
+	if err != nil {
+		return err
+	}
+
+	symbol := binance.Symbol(order.Symbol.UpperCase())
+
+	var open []binance.Order
+	if g.maxOpenOrders > 0 || len(g.maxPosition) > 0 {
+		open, err = g.client.OpenOrders(symbol)
+		if err != nil {
+			return err
+		}
+	}
+
+	if replaced != nil {
+		var kept []binance.Order
+		for _, o := range open {
+			if !replaced(o) {
+				kept = append(kept, o)
+			}
+		}
+
+		open = kept
+	}
+
+	if g.maxOpenOrders > 0 && len(open)+placed > g.maxOpenOrders {
+		return reject(RuleMaxOpenOrders, order, "%d orders already open", len(open))
+	}
+
+	price, quantity, err := g.estimate(symbol, order)
+	if err != nil {
+		return err
+	}
+
+	notional := price * quantity
+	if order.QuoteOrderQuantity != "" {
+		notional = order.QuoteOrderQuantity.Float64()
+	}
+
+	err = g.checkNotional(symbol, order, notional)
+	if err != nil {
+		return err
+	}
+
+	return g.checkPosition(symbol, order, quantity, open)
+}
+
+// checkNotional checks notional against the limit for the quote asset of
+// symbol.
+func (g *Guard) checkNotional(symbol binance.Symbol, order *binance.Order, notional float64) error {
+	if len(g.maxNotional) == 0 {
+		return nil
+	}
+
+	info, found := g.symbols[symbol]
+	if !found {
+		return reject(RuleMaxNotional, order, "unknown symbol")
+	}
+
+	limit, found := g.maxNotional[info.QuoteAsset]
+	if !found {
+		return reject(RuleMaxNotional, order, "no limit for %s", info.QuoteAsset)
+	}
+
+	if notional > limit {
+		return reject(RuleMaxNotional, order, "notional %g %s is above the limit of %g", notional, info.QuoteAsset, limit)
+	}
+
+	return nil
+}
+
+// estimate returns the price and quantity order is expected to trade at,
+// and checks the limit price against the price band.
+func (g *Guard) estimate(symbol binance.Symbol, order *binance.Order) (float64, float64, error) {
+	price := order.Price.Float64()
+	if price == 0 {
+		price = order.StopPrice.Float64()
+	}
+
+	// The best price is only needed for market orders and the price band.
+	if price != 0 && (g.priceBand <= 0 || order.Price == "") {
+		return price, order.Quantity.Float64(), nil
+	}
+
+	best, err := g.client.BestPrice(symbol)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	reference := best.Ask.Price.Float64()
+	if order.Side == binance.OrderSideSell {
+		reference = best.Bid.Price.Float64()
+	}
+
+	if reference <= 0 {
+		return 0, 0, reject(RulePriceBand, order, "no price in the order book")
+	}
+
+	if g.priceBand > 0 && order.Price != "" {
+		deviation := (price - reference) / reference
+		if deviation > g.priceBand || deviation < -g.priceBand {
+			return 0, 0, reject(RulePriceBand, order, "price %s is %.1f%% from %g", order.Price, deviation*100, reference)
+		}
+	}
+
+	if price == 0 {
+		price = reference
+	}
+
+	quantity := order.Quantity.Float64()
+	if order.QuoteOrderQuantity != "" {
+		quantity = order.QuoteOrderQuantity.Float64() / price
+	}
+
+	return price, quantity, nil
+}
+
+// checkPosition checks that the base asset bought by order stays within the
+// position limit.
+func (g *Guard) checkPosition(symbol binance.Symbol, order *binance.Order, quantity float64, open []binance.Order) error {
+	if len(g.maxPosition) == 0 || order.Side != binance.OrderSideBuy {
+		return nil
+	}
+
+	info, found := g.symbols[symbol]
+	if !found {
+		return reject(RuleMaxPosition, order, "unknown symbol")
+	}
+
+	limit, found := g.maxPosition[info.BaseAsset]
+	if !found {
+		return nil
+	}
+
+	account, err := g.client.AccountInfo()
+	if err != nil {
+		return err
+	}
+
+	position := quantity + account.Total(info.BaseAsset)
+
+	for _, o := range open {
+		if o.Side == binance.OrderSideBuy {
+			position += o.Quantity.Float64() - o.ExecutedQuantity.Float64()
+		}
+	}
+
+	if position > limit {
+		return reject(RuleMaxPosition, order, "position in %s would be %g, above the limit of %g", info.BaseAsset, position, limit)
+	}
+
+	return nil
+}
+
+// dailyStart will remember the realized PnL at the start of a new day.
+func (g *Guard) dailyStart() {
+	day := g.now().UTC().Truncate(24 * time.Hour)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if !day.Equal(g.day) {
+		g.day = day
+		g.dayPnL = g.pnl.Realized()
+	}
+}
+
+// checkDailyLoss checks the realized loss of the day.
+func (g *Guard) checkDailyLoss(order *binance.Order) error {
+	if g.pnl == nil || g.dailyLoss <= 0 {
+		return nil
+	}
+
+	g.dailyStart()
+
+	g.mu.Lock()
+	loss := g.dayPnL - g.pnl.Realized()
+	g.mu.Unlock()
+
+	if loss >= g.dailyLoss {
+		return reject(RuleDailyLoss, order, "lost %g today, the limit is %g", loss, g.dailyLoss)
+	}
+
+	return nil
+}
@@ -0,0 +1,350 @@
+package risk
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	binance "github.com/algoholdet/gobinance"
+)
+
+// fakeClient keeps open orders and a fixed market and account.
+type fakeClient struct {
+	mu        sync.Mutex
+	open      []binance.Order
+	submitted int
+	canceled  []binance.Symbol
+	best      binance.BestPrice
+	balances  map[string]binance.Value
+}
+
+func newFakeClient() *fakeClient {
+	c := &fakeClient{balances: map[string]binance.Value{"BTC": "1", "USDT": "100000"}}
+	c.best.Bid.Price = "99"
+	c.best.Ask.Price = "101"
+
+	return c
+}
+
+func (c *fakeClient) SubmitOrder(order *binance.Order) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.submitted++
+	if order.Type != binance.OrderTypeMarket {
+		c.open = append(c.open, *order)
+	}
+
+	return nil
+}
+
+func (c *fakeClient) CancelReplaceOrder(mode binance.CancelReplaceMode, cancelClientOrderID string, cancelID int, order *binance.Order) (*binance.CancelReplaceResult, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.submitted++
+
+	return &binance.CancelReplaceResult{}, nil
+}
+
+func (c *fakeClient) SubmitOCO(oco *binance.OCO) (*binance.OrderList, error) {
+	return c.submitList()
+}
+
+func (c *fakeClient) SubmitOTO(oto *binance.OTO) (*binance.OrderList, error) {
+	return c.submitList()
+}
+
+func (c *fakeClient) SubmitOTOCO(otoco *binance.OTOCO) (*binance.OrderList, error) {
+	return c.submitList()
+}
+
+func (c *fakeClient) submitList() (*binance.OrderList, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.submitted++
+
+	return &binance.OrderList{}, nil
+}
+
+func (c *fakeClient) OrderStatus(symbol binance.Symbol, clientOrderID string, id int) (*binance.Order, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (c *fakeClient) CancelOrder(symbol binance.Symbol, clientOrderID string, id int) (*binance.Order, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (c *fakeClient) CancelAllOrders(symbol binance.Symbol) ([]binance.Order, []binance.OrderList, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.canceled = append(c.canceled, symbol)
+
+	var open []binance.Order
+	for _, o := range c.open {
+		if o.Symbol != symbol {
+			open = append(open, o)
+		}
+	}
+
+	c.open = open
+
+	return nil, nil, nil
+}
+
+func (c *fakeClient) OpenOrders(symbol binance.Symbol) ([]binance.Order, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var open []binance.Order
+	for _, o := range c.open {
+		if symbol == "" || o.Symbol == symbol {
+			open = append(open, o)
+		}
+	}
+
+	return open, nil
+}
+
+func (c *fakeClient) BestPrice(symbol binance.Symbol) (*binance.BestPrice, error) {
+	best := c.best
+	return &best, nil
+}
+
+func (c *fakeClient) AccountInfo() (*binance.AccountInfo, error) {
+	info := &binance.AccountInfo{}
+
+	for asset, free := range c.balances {
+		info.Balances = append(info.Balances, binance.Balance{Asset: asset, Free: free, Locked: "0"})
+	}
+
+	return info, nil
+}
+
+// pnl is a fixed RealizedPnL.
+type pnl float64
+
+func (p *pnl) Realized() float64 {
+	return float64(*p)
+}
+
+func rule(err error) Rule {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Rule
+	}
+
+	return ""
+}
+
+func TestGuardLimits(t *testing.T) {
+	client := newFakeClient()
+	client.open = []binance.Order{{Symbol: "ETHUSDT", Side: binance.OrderSideBuy, Quantity: "1"}}
+
+	g := New(client,
+		Symbols(
+			binance.SymbolInfo{Symbol: "BTCUSDT", BaseAsset: "BTC", QuoteAsset: "USDT"},
+			binance.SymbolInfo{Symbol: "ETHBTC", BaseAsset: "ETH", QuoteAsset: "BTC"},
+		),
+		MaxNotional("USDT", 1000),
+		MaxPosition("BTC", 5),
+		MaxOpenOrders(2),
+		PriceBand(0.1),
+	)
+
+	limit := func(side binance.OrderSide, quantity binance.Value, price binance.Value) *binance.Order {
+		order, _ := binance.LimitOrder("BTCUSDT", side, quantity, price)
+		return order
+	}
+
+	market, _ := binance.MarketBuy("BTCUSDT", "100")
+	marketQuote, _ := binance.MarketQuoteOrder("BTCUSDT", binance.OrderSideBuy, "5000")
+	unknown, _ := binance.MarketBuy("ETHUSDT", "1")
+	unlimited, _ := binance.LimitOrder("ETHBTC", binance.OrderSideBuy, "1", "100")
+
+	cases := []struct {
+		order    *binance.Order
+		expected Rule
+	}{
+		{limit(binance.OrderSideBuy, "1", "100"), ""},
+		// 100× too large.
+		{limit(binance.OrderSideBuy, "100", "100"), RuleMaxNotional},
+		{market, RuleMaxNotional},
+		{marketQuote, RuleMaxNotional},
+		// Fat fingers.
+		{limit(binance.OrderSideBuy, "1", "1000"), RulePriceBand},
+		{limit(binance.OrderSideSell, "1", "10"), RulePriceBand},
+		{limit(binance.OrderSideSell, "1", "95"), ""},
+		// Two orders are open already.
+		{limit(binance.OrderSideBuy, "4", "100"), RuleMaxOpenOrders},
+		{unknown, RuleMaxNotional},
+		// No limit for BTC.
+		{unlimited, RuleMaxNotional},
+	}
+
+	for i, c := range cases {
+		err := g.SubmitOrder(c.order)
+		if rule(err) != c.expected || (c.expected == "" && err != nil) {
+			t.Errorf("%d: got %v, expected %s", i, err, c.expected)
+		}
+	}
+
+	if client.submitted != 2 {
+		t.Errorf("%d orders submitted, expected 2", client.submitted)
+	}
+
+	// Leave the buy open. 1 held, 1 bought and 4 more would be 6.
+	client.open = client.open[:2]
+
+	err := g.SubmitOrder(limit(binance.OrderSideBuy, "4", "100"))
+	if rule(err) != RuleMaxPosition {
+		t.Errorf("position limit not enforced: %v", err)
+	}
+
+	err = g.SubmitOrder(limit(binance.OrderSideBuy, "3", "100"))
+	if err != nil {
+		t.Errorf("order within limits rejected: %v", err)
+	}
+}
+
+func TestGuardDailyLoss(t *testing.T) {
+	realized := pnl(500)
+	// Noon of the day the guard is created.
+	now := time.Now().UTC().Truncate(24 * time.Hour).Add(12 * time.Hour)
+
+	client := newFakeClient()
+	g := New(client, DailyLossLimit(&realized, 100))
+	g.now = func() time.Time { return now }
+
+	order, _ := binance.MarketBuy("BTCUSDT", "1")
+
+	realized = 450
+	if err := g.SubmitOrder(order); err != nil {
+		t.Errorf("order rejected after small loss: %v", err)
+	}
+
+	realized = 400
+	if err := g.SubmitOrder(order); rule(err) != RuleDailyLoss {
+		t.Errorf("daily loss not enforced: %v", err)
+	}
+
+	// A new day starts from the current PnL.
+	now = now.Add(24 * time.Hour)
+	if err := g.SubmitOrder(order); err != nil {
+		t.Errorf("order rejected on a new day: %v", err)
+	}
+}
+
+func TestGuardKill(t *testing.T) {
+	client := newFakeClient()
+	client.open = []binance.Order{
+		{Symbol: "BTCUSDT"},
+		{Symbol: "ETHUSDT"},
+		{Symbol: "BTCUSDT"},
+	}
+
+	g := New(client)
+
+	err := g.Kill()
+	if err != nil {
+		t.Fatalf("Kill failed: %s", err.Error())
+	}
+
+	if len(client.open) != 0 || len(client.canceled) != 2 {
+		t.Errorf("got open %v and canceled %v", client.open, client.canceled)
+	}
+
+	order, _ := binance.MarketBuy("BTCUSDT", "1")
+	if err := g.SubmitOrder(order); rule(err) != RuleKillSwitch || !g.Killed() {
+		t.Errorf("order not blocked: %v", err)
+	}
+
+	g.Resume()
+	if err := g.SubmitOrder(order); err != nil {
+		t.Errorf("order blocked after resume: %v", err)
+	}
+}
+
+func TestGuardOrderPaths(t *testing.T) {
+	client := newFakeClient()
+
+	g := New(client,
+		Symbols(binance.SymbolInfo{Symbol: "BTCUSDT", BaseAsset: "BTC", QuoteAsset: "USDT"}),
+		MaxNotional("USDT", 1000),
+		MaxOpenOrders(2),
+	)
+
+	oco := func(quantity binance.Value) *binance.OCO {
+		return &binance.OCO{
+			Symbol:   "BTCUSDT",
+			Side:     binance.OrderSideSell,
+			Quantity: quantity,
+			Above:    &binance.Order{Type: binance.OrderTypeLimitMaker, Price: "105"},
+			Below:    &binance.Order{Type: binance.OrderTypeStopLoss, StopPrice: "95"},
+		}
+	}
+
+	limit := func(quantity binance.Value) *binance.Order {
+		order, _ := binance.LimitOrder("BTCUSDT", binance.OrderSideBuy, quantity, "100")
+		return order
+	}
+
+	// The legs take the quantity from the list.
+	if _, err := g.SubmitOCO(oco("100")); rule(err) != RuleMaxNotional {
+		t.Errorf("OCO notional not enforced: %v", err)
+	}
+
+	if _, err := g.SubmitOTO(&binance.OTO{Working: limit("100"), Pending: limit("1")}); rule(err) != RuleMaxNotional {
+		t.Errorf("OTO notional not enforced: %v", err)
+	}
+
+	if _, err := g.CancelReplaceOrder(binance.CancelReplaceStopOnFailure, "", 1, limit("100")); rule(err) != RuleMaxNotional {
+		t.Errorf("replace notional not enforced: %v", err)
+	}
+
+	client.open = []binance.Order{{Symbol: "BTCUSDT", ID: 1}}
+
+	// Both legs are placed, which would make three open orders.
+	if _, err := g.SubmitOCO(oco("1")); rule(err) != RuleMaxOpenOrders {
+		t.Errorf("OCO open orders not enforced: %v", err)
+	}
+
+	if _, err := g.SubmitOTOCO(&binance.OTOCO{Working: limit("1")}); err == nil {
+		t.Errorf("OTOCO with missing orders accepted")
+	}
+
+	// The replaced order is canceled first, unless the new order is
+	// placed anyway.
+	if _, err := g.CancelReplaceOrder(binance.CancelReplaceAllowFailure, "", 1, limit("1")); err != nil {
+		t.Errorf("replace within limits rejected: %v", err)
+	}
+
+	client.open = append(client.open, binance.Order{Symbol: "BTCUSDT", ID: 2})
+
+	if _, err := g.CancelReplaceOrder(binance.CancelReplaceAllowFailure, "", 1, limit("1")); rule(err) != RuleMaxOpenOrders {
+		t.Errorf("replace open orders not enforced: %v", err)
+	}
+
+	if _, err := g.CancelReplaceOrder(binance.CancelReplaceStopOnFailure, "", 1, limit("1")); err != nil {
+		t.Errorf("replace of an open order rejected: %v", err)
+	}
+
+	client.open = nil
+
+	if _, err := g.SubmitOCO(oco("1")); err != nil {
+		t.Errorf("OCO within limits rejected: %v", err)
+	}
+
+	_ = g.Kill()
+
+	if _, err := g.SubmitOTO(&binance.OTO{Working: limit("1"), Pending: limit("1")}); rule(err) != RuleKillSwitch {
+		t.Errorf("OTO not blocked: %v", err)
+	}
+
+	if client.submitted != 3 {
+		t.Errorf("%d orders submitted, expected 3", client.submitted)
+	}
+}
@@ -1,8 +1,10 @@
 package binance
 
 import (
+	"crypto/ed25519"
 	"crypto/hmac"
 	"crypto/sha256"
+	"encoding/base64"
 	"fmt"
 )
 
@@ -15,3 +17,9 @@ func signString(in string, key string) string {
 
 	return fmt.Sprintf("%x", (mac.Sum(nil)))
 }
+
+// signEd25519 will sign a string using an Ed25519 key. The signature is
+// returned base64 encoded as expected by Binance.
+func signEd25519(in string, key ed25519.PrivateKey) string {
+	return base64.StdEncoding.EncodeToString(ed25519.Sign(key, []byte(in)))
+}
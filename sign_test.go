@@ -1,6 +1,8 @@
 package binance
 
 import (
+	"crypto/ed25519"
+	"encoding/base64"
 	"testing"
 )
 
@@ -17,3 +19,17 @@ func TestSignString(t *testing.T) {
 		t.Errorf("Got %s, expected %s", out, expected)
 	}
 }
+
+func TestSignEd25519(t *testing.T) {
+	key := ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize))
+	in := "apiKey=key&timestamp=1649729878532"
+
+	signature, err := base64.StdEncoding.DecodeString(signEd25519(in, key))
+	if err != nil {
+		t.Fatalf("Signature is not base64: %s", err.Error())
+	}
+
+	if !ed25519.Verify(key.Public().(ed25519.PublicKey), []byte(in), signature) {
+		t.Errorf("Signature did not verify")
+	}
+}
@@ -0,0 +1,105 @@
+package binance
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// weightLimiter keeps track of the request weight used in the current
+// minute, as reported by Binance. It's used for waiting before sending
+// requests that would exceed the limit. Binance counts weight per IP, so a
+// limiter can be shared by clients using the same IP.
+type weightLimiter struct {
+	mu sync.Mutex
+
+	// limit is the weight we allow ourselves to use per minute. Zero means
+	// no limit.
+	limit int
+
+	used   int
+	minute time.Time
+
+	// blockedUntil is set when Binance tells us to back off.
+	blockedUntil time.Time
+}
+
+// delay returns how long to wait before sending a request at now.
+func (l *weightLimiter) delay(now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Before(l.blockedUntil) {
+		return l.blockedUntil.Sub(now)
+	}
+
+	if l.limit > 0 && l.used >= l.limit && now.Truncate(time.Minute).Equal(l.minute) {
+		return l.minute.Add(time.Minute).Sub(now)
+	}
+
+	return 0
+}
+
+// wait will block until a request can be sent.
+func (l *weightLimiter) wait() {
+	d := l.delay(time.Now())
+	if d > 0 {
+		time.Sleep(d)
+	}
+}
+
+// update will record the weight and back off instructions from a response
+// received at now.
+func (l *weightLimiter) update(now time.Time, response *http.Response) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if uw, err := strconv.Atoi(response.Header.Get("X-Mbx-Used-Weight-1m")); err == nil {
+		l.used = uw
+		l.minute = now.Truncate(time.Minute)
+	}
+
+	switch response.StatusCode {
+	case http.StatusTooManyRequests, http.StatusTeapot:
+		retryAfter, err := strconv.Atoi(response.Header.Get("Retry-After"))
+		if err != nil {
+			// We have no idea how long to wait, wait for the next minute.
+			l.blockedUntil = now.Truncate(time.Minute).Add(time.Minute)
+			return
+		}
+
+		l.blockedUntil = now.Add(time.Duration(retryAfter) * time.Second)
+	}
+}
+
+// usedWeight returns the weight used in the current minute.
+func (l *weightLimiter) usedWeight() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !time.Now().Truncate(time.Minute).Equal(l.minute) {
+		return 0
+	}
+
+	return l.used
+}
+
+// WeightLimit will make the client wait before sending requests, if the
+// weight used in the current minute has reached limit. The weight is
+// reported by Binance and includes requests from other clients on the same
+// IP. Clients will always wait if Binance answers 429 or 418.
+func WeightLimit(limit int) func(*Client) {
+	return func(c *Client) {
+		c.weight.mu.Lock()
+		c.weight.limit = limit
+		c.weight.mu.Unlock()
+	}
+}
+
+// rateLimited returns true if err tells that we were rate limited.
+func rateLimited(err error) bool {
+	e, ok := err.(*APIError)
+
+	return ok && e.StatusCode == http.StatusTooManyRequests
+}
@@ -0,0 +1,48 @@
+package binance
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestWeightLimiter(t *testing.T) {
+	now := time.Date(2020, 1, 1, 12, 0, 30, 0, time.UTC)
+
+	response := func(status int, weight string, retryAfter string) *http.Response {
+		r := &http.Response{StatusCode: status, Header: http.Header{}}
+		if weight != "" {
+			r.Header.Set("X-Mbx-Used-Weight-1m", weight)
+		}
+		if retryAfter != "" {
+			r.Header.Set("Retry-After", retryAfter)
+		}
+
+		return r
+	}
+
+	cases := []struct {
+		limit    int
+		response *http.Response
+		at       time.Time
+		expected time.Duration
+	}{
+		{0, response(200, "6000", ""), now, 0},
+		{1000, response(200, "999", ""), now, 0},
+		{1000, response(200, "1000", ""), now, 30 * time.Second},
+		{1000, response(200, "1000", ""), now.Add(30 * time.Second), 0},
+		{0, response(429, "6001", "10"), now, 10 * time.Second},
+		{0, response(429, "6001", "10"), now.Add(5 * time.Second), 5 * time.Second},
+		{0, response(418, "", ""), now, 30 * time.Second},
+	}
+
+	for i, c := range cases {
+		l := &weightLimiter{limit: c.limit}
+		l.update(now, c.response)
+
+		d := l.delay(c.at)
+		if d != c.expected {
+			t.Errorf("case %d: got delay %s, expected %s", i, d, c.expected)
+		}
+	}
+}